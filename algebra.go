@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// variableAnswer lets the player write "x = 5" as well as just "5"
+type variableAnswer struct {
+	answer
+}
+
+func (a variableAnswer) Parse(s string) (answer, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(strings.ToLower(s), "x"); ok {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(rest), "="); ok {
+			s = value
+		}
+	}
+	return a.answer.Parse(s)
+}
+
+// NewAlgebraProblems makes one and two step equations to solve for x, like "3x + 4 = 19".
+// Unless fractional, x is always a whole number.
+func NewAlgebraProblems(fractional bool) problems {
+	return NewSampledProblems(sampleSize, func() problem {
+		// The equation is ax + b = c or x/a + b = c, where a or b can be left out
+		a, divide := 1, false
+		switch rand.Intn(3) {
+		case 1:
+			a = 2 + rand.Intn(8)
+		case 2:
+			a, divide = 2+rand.Intn(8), true
+		}
+		b := 0
+		if a == 1 || rand.Intn(2) == 0 {
+			b = 1 + rand.Intn(20)
+		}
+
+		x := newFraction(1+rand.Intn(12), 1)
+		var ax fraction
+		switch {
+		case divide:
+			x = x.mul(newFraction(a, 1)) // So x/a is a whole number
+			ax = x.div(newFraction(a, 1))
+		case fractional && a != 1 && rand.Intn(2) == 0:
+			x = newFraction(a*x.num+1+rand.Intn(a-1), a) // Won't divide evenly
+			ax = x.mul(newFraction(a, 1))
+		default:
+			ax = x.mul(newFraction(a, 1))
+		}
+		if rand.Intn(2) == 0 && ax.num > b {
+			b = -b
+		}
+		c := ax.num + b
+
+		term := "x"
+		switch {
+		case divide:
+			term = fmt.Sprintf("x/%d", a)
+		case a != 1:
+			term = fmt.Sprintf("%dx", a)
+		}
+		q := term
+		switch {
+		case b > 0:
+			q += fmt.Sprintf(" + %d", b)
+		case b < 0:
+			q += fmt.Sprintf(" - %d", -b)
+		}
+		q += fmt.Sprintf(" = %d", c)
+
+		// Undo each step, the opposite of the order of operations
+		steps := []string{q}
+		switch {
+		case b > 0:
+			steps = append(steps, fmt.Sprintf("Subtract %d from both sides: %s = %d", b, term, ax.num))
+		case b < 0:
+			steps = append(steps, fmt.Sprintf("Add %d to both sides: %s = %d", -b, term, ax.num))
+		}
+		switch {
+		case divide:
+			steps = append(steps, fmt.Sprintf("Multiply both sides by %d: x = %s", a, x))
+		case a != 1:
+			steps = append(steps, fmt.Sprintf("Divide both sides by %d: x = %s", a, x))
+		}
+
+		var ans answer = intAnswer(x.num)
+		if fractional {
+			ans = fracAnswer{value: x}
+		}
+		prob := NewProblem(q, variableAnswer{ans})
+		prob.prompt = "Solve for x: " + q
+		prob.solution = "x = " + x.String()
+		prob.steps = strings.Join(steps, "\n")
+		return prob
+	})
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestVariableAnswer(t *testing.T) {
+	five := variableAnswer{intAnswer(5)}
+	third := variableAnswer{fracAnswer{value: newFraction(2, 3)}}
+	checkAnswers(t, []answerTest{
+		{"x equals", five, "x = 5", true, "", false},
+		{"bare", five, "5", true, "", false},
+		{"no spaces", five, "x=5", true, "", false},
+		{"negative", variableAnswer{intAnswer(-4)}, "x = −4", true, "", false},
+		{"wrong", five, "x = 6", false, "", false},
+		{"fraction", third, "X=2/3", true, "", false},
+		{"equal fraction", third, "x = 4/6", true, "", false},
+		{"no value", five, "x =", false, "", true},
+		{"other letter", five, "y = 5", false, "", true},
+	})
+	checkRoundTrip(t, five, third, variableAnswer{intAnswer(-4)}, variableAnswer{fracAnswer{value: newFraction(5, 3)}})
+}
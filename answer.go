@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// answer is the solution to a problem. Each kind of problem supplies its own,
+// so it decides how the player's input is read, compared and shown.
+type answer interface {
+	// Parse reads the player's input as the same kind of answer. The error
+	// is shown to the player, so it should say what we expected.
+	Parse(s string) (answer, error)
+	// Check compares the player's parsed answer to this one. It can allow some
+	// tolerance, like for estimates, and can return a hint when the answer is wrong.
+	Check(given answer) (correct bool, hint string)
+	// String formats the answer for feedback
+	String() string
+}
+
+// normalizeMinus swaps the unicode minus sign for a dash, and removes any space after a leading one
+func normalizeMinus(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "−", "-")
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		return "-" + strings.TrimSpace(rest)
+	}
+	return s
+}
+
+// intAnswer is a whole number
+type intAnswer int
+
+func (a intAnswer) Parse(s string) (answer, error) {
+	n, err := strconv.Atoi(strings.ReplaceAll(normalizeMinus(s), ",", "")) // Allow 3,500
+	if err != nil {
+		return nil, errors.New("please enter a number")
+	}
+	return intAnswer(n), nil
+}
+
+func (a intAnswer) Check(given answer) (bool, string) {
+	return given == a, ""
+}
+
+func (a intAnswer) String() string {
+	return strconv.Itoa(int(a))
+}
+
+// remainderAnswer is a quotient and remainder, like "3 R 2"
+type remainderAnswer struct {
+	quotient  int
+	remainder int
+}
+
+// Parse reads answers like "3 R 2", "3r2" or just "3" when there is no remainder
+func (a remainderAnswer) Parse(s string) (answer, error) {
+	errFormat := errors.New("please enter an answer like 3 R 2")
+	q, r, found := strings.Cut(strings.ToLower(s), "r")
+	quotient, err := strconv.Atoi(strings.TrimSpace(q))
+	if err != nil {
+		return nil, errFormat
+	}
+	if !found {
+		return remainderAnswer{quotient: quotient}, nil
+	}
+	remainder, err := strconv.Atoi(strings.TrimSpace(r))
+	if err != nil {
+		return nil, errFormat
+	}
+	return remainderAnswer{quotient: quotient, remainder: remainder}, nil
+}
+
+func (a remainderAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(remainderAnswer)
+	switch {
+	case !ok:
+		return false, ""
+	case g == a:
+		return true, ""
+	case g.quotient == a.quotient:
+		return false, "So close, the quotient is right but the remainder isn't."
+	case g.remainder == a.remainder && a.remainder != 0:
+		return false, "So close, the remainder is right but the quotient isn't."
+	}
+	return false, ""
+}
+
+func (a remainderAnswer) String() string {
+	return fmt.Sprintf("%d R %d", a.quotient, a.remainder)
+}
+
+// yesNoAnswer is for questions like "Is 51 prime?"
+type yesNoAnswer bool
+
+func (a yesNoAnswer) Parse(s string) (answer, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "yes", "y":
+		return yesNoAnswer(true), nil
+	case "no", "n":
+		return yesNoAnswer(false), nil
+	}
+	return nil, errors.New("please enter yes or no")
+}
+
+func (a yesNoAnswer) Check(given answer) (bool, string) {
+	return given == a, ""
+}
+
+func (a yesNoAnswer) String() string {
+	if a {
+		return "yes"
+	}
+	return "no"
+}
+
+// setAnswer is a list of numbers in any order, like the factors of a number
+type setAnswer []int
+
+// Parse reads numbers separated by commas or spaces, like "1, 2, 4" or "4 2 1"
+func (a setAnswer) Parse(s string) (answer, error) {
+	var set setAnswer
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		n, err := strconv.Atoi(normalizeMinus(field))
+		if err != nil {
+			return nil, errors.New("please enter numbers separated by commas, like 1, 2, 4")
+		}
+		if !slices.Contains(set, n) {
+			set = append(set, n)
+		}
+	}
+	slices.Sort(set)
+	return set, nil
+}
+
+func (a setAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(setAnswer)
+	if !ok {
+		return false, ""
+	}
+	var extra, missing []string
+	for _, n := range g {
+		if !slices.Contains(a, n) {
+			extra = append(extra, strconv.Itoa(n))
+		}
+	}
+	for _, n := range a {
+		if !slices.Contains(g, n) {
+			missing = append(missing, strconv.Itoa(n))
+		}
+	}
+	switch {
+	case len(extra) > 0:
+		return false, fmt.Sprintf("Not quite, %s shouldn't be there.", strings.Join(extra, ", "))
+	case len(missing) > 0:
+		return false, fmt.Sprintf("Almost, you missed %d of them.", len(missing))
+	}
+	return true, ""
+}
+
+func (a setAnswer) String() string {
+	s := make([]string, len(a))
+	for i, n := range a {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+// approxAnswer accepts any number within tolerance of the value, like for estimates
+type approxAnswer struct {
+	value     int
+	tolerance int
+}
+
+func (a approxAnswer) Parse(s string) (answer, error) {
+	n, err := intAnswer(0).Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return approxAnswer{value: int(n.(intAnswer))}, nil
+}
+
+func (a approxAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(approxAnswer)
+	if !ok {
+		return false, ""
+	}
+	return max(g.value-a.value, a.value-g.value) <= a.tolerance, ""
+}
+
+func (a approxAnswer) String() string {
+	return "about " + strconv.Itoa(a.value)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// answerTest is the player typing input for the answer ans
+type answerTest struct {
+	name    string
+	ans     answer
+	input   string
+	correct bool
+	hint    string
+	err     bool // Parse should fail
+}
+
+// checkAnswers parses each input the way the game does, then checks it
+func checkAnswers(t *testing.T, tests []answerTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			given, err := tt.ans.Parse(tt.input)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %v, want an error", tt.input, given)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			correct, hint := tt.ans.Check(given)
+			if correct != tt.correct || hint != tt.hint {
+				t.Errorf("Check(%q) = %v, %q, want %v, %q", tt.input, correct, hint, tt.correct, tt.hint)
+			}
+		})
+	}
+}
+
+// checkRoundTrip makes sure typing back the answer shown in feedback is right, except
+// for answers shown as "about ..."
+func checkRoundTrip(t *testing.T, answers ...answer) {
+	t.Helper()
+	for _, ans := range answers {
+		s := ans.String()
+		if strings.HasPrefix(s, "about ") {
+			continue
+		}
+		given, err := ans.Parse(s)
+		if err != nil {
+			t.Errorf("%T: Parse(%q) failed: %v", ans, s, err)
+			continue
+		}
+		if correct, hint := ans.Check(given); !correct {
+			t.Errorf("%T: Check(%q) = false, %q", ans, s, hint)
+		}
+	}
+}
+
+func TestIntAnswer(t *testing.T) {
+	checkAnswers(t, []answerTest{
+		{"right", intAnswer(56), "56", true, "", false},
+		{"negative", intAnswer(-5), "-5", true, "", false},
+		{"comma", intAnswer(3500), "3,500", true, "", false},
+		{"unicode minus", intAnswer(-5), "−5", true, "", false},
+		{"minus with space", intAnswer(-5), "- 5", true, "", false},
+		{"wrong", intAnswer(56), "54", false, "", false},
+		{"not a number", intAnswer(56), "fifty", false, "", true},
+	})
+	checkRoundTrip(t, intAnswer(56), intAnswer(-12))
+}
+
+func TestRemainderAnswer(t *testing.T) {
+	checkAnswers(t, []answerTest{
+		{"right", remainderAnswer{3, 2}, "3 R 2", true, "", false},
+		{"short", remainderAnswer{3, 2}, "3r2", true, "", false},
+		{"no remainder", remainderAnswer{4, 0}, "4", true, "", false},
+		{"zero remainder", remainderAnswer{4, 0}, "4 R 0", true, "", false},
+		{"wrong remainder", remainderAnswer{3, 2}, "3r1", false, "So close, the quotient is right but the remainder isn't.", false},
+		{"wrong quotient", remainderAnswer{3, 2}, "4r2", false, "So close, the remainder is right but the quotient isn't.", false},
+		{"both wrong", remainderAnswer{3, 2}, "4r1", false, "", false},
+		{"no remainder after r", remainderAnswer{3, 2}, "3 R", false, "", true},
+	})
+	checkRoundTrip(t, remainderAnswer{7, 3}, remainderAnswer{4, 0})
+}
+
+func TestNormalizeMinus(t *testing.T) {
+	for input, want := range map[string]string{"−5": "-5", " - 5 ": "-5", "5": "5", "−1 1/2": "-1 1/2"} {
+		if got := normalizeMinus(input); got != want {
+			t.Errorf("normalizeMinus(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestYesNoAnswer(t *testing.T) {
+	checkAnswers(t, []answerTest{
+		{"yes", yesNoAnswer(true), "yes", true, "", false},
+		{"y", yesNoAnswer(true), "y", true, "", false},
+		{"no", yesNoAnswer(false), " No ", true, "", false},
+		{"wrong", yesNoAnswer(true), "n", false, "", false},
+		{"not yes or no", yesNoAnswer(true), "maybe", false, "", true},
+	})
+	checkRoundTrip(t, yesNoAnswer(true), yesNoAnswer(false))
+}
+
+func TestSetAnswer(t *testing.T) {
+	factors := setAnswer{1, 2, 4}
+	checkAnswers(t, []answerTest{
+		{"in order", factors, "1, 2, 4", true, "", false},
+		{"any order", factors, "4 2, 1", true, "", false},
+		{"repeated", factors, "1, 2, 2, 4", true, "", false},
+		{"missing", factors, "1, 2", false, "Almost, you missed 1 of them.", false},
+		{"extra", factors, "1, 2, 3, 4", false, "Not quite, 3 shouldn't be there.", false},
+		{"not numbers", factors, "1, two, 4", false, "", true},
+	})
+	checkRoundTrip(t, factors, setAnswer{7})
+}
+
+func TestApproxAnswer(t *testing.T) {
+	estimate := approxAnswer{value: 300, tolerance: 50}
+	checkAnswers(t, []answerTest{
+		{"exact", estimate, "300", true, "", false},
+		{"within", estimate, "320", true, "", false},
+		{"edge", estimate, "250", true, "", false},
+		{"outside", estimate, "400", false, "", false},
+		{"comma", approxAnswer{value: 3000, tolerance: 500}, "3,200", true, "", false},
+		{"not a number", estimate, "lots", false, "", true},
+	})
+	checkRoundTrip(t, estimate)
+}
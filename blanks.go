@@ -0,0 +1,36 @@
+package main
+
+import "fmt"
+
+// NewBlankProblems turns "a op b = ?" problems into fill in the blank ones, like "7 x ? = 56"
+// or "? - 9 = 4", taking turns on which number is hidden
+func NewBlankProblems(p problems, md mode) problems {
+	out := make(problems, 0, len(p))
+	for i, prob := range p {
+		var a, b, c int
+		var op string
+		switch md {
+		case modeAdd:
+			a, b, c, op = prob.a, prob.b, prob.a+prob.b, "+"
+		case modeSub:
+			a, b, c, op = prob.a, prob.b, prob.a-prob.b, "-"
+		case modeMul:
+			a, b, c, op = prob.a, prob.b, prob.a*prob.b, "x"
+		case modeDiv:
+			a, b, c, op = prob.a*prob.b, prob.a, prob.b, "/"
+		default:
+			return p
+		}
+
+		q, ans := fmt.Sprintf("? %s %d = %d", op, b, c), a
+		if i%2 == 1 {
+			q, ans = fmt.Sprintf("%d %s ? = %d", a, op, c), b
+		}
+		blank := NewProblem(q, intAnswer(ans))
+		blank.prompt = q
+		blank.solution = fmt.Sprintf("%d %s %d = %d", a, op, b, c)
+		blank.a, blank.b = prob.a, prob.b
+		out = append(out, blank)
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewBlankProblems(t *testing.T) {
+	for md, p := range map[mode]problems{
+		modeAdd: NewAddProblems(1),
+		modeSub: NewSubProblems(1),
+		modeMul: NewMulProblems(7),
+		modeDiv: NewDivProblems(7),
+	} {
+		blanks := NewBlankProblems(p, md)
+		if len(blanks) != len(p) {
+			t.Fatalf("%s: got %d problems, want %d", md, len(blanks), len(p))
+		}
+		for i, prob := range blanks {
+			// The answer goes in the blank, so filling it in gives the solution
+			if got := strings.Replace(prob.question, "?", prob.answer.String(), 1); got != prob.solution {
+				t.Errorf("%s: %q with %s filled in is %q, want %q", md, prob.question, prob.answer, got, prob.solution)
+			}
+			if blankFirst := strings.HasPrefix(prob.question, "?"); blankFirst != (i%2 == 0) {
+				t.Errorf("%s: problem %d is %q, want the blank to take turns", md, i, prob.question)
+			}
+			if prob.Prompt() != prob.question || prob.a != p[i].a || prob.b != p[i].b {
+				t.Errorf("%s: got prompt %q and fact %d, %d, want %q and %d, %d", md, prob.Prompt(), prob.a, prob.b, prob.question, p[i].a, p[i].b)
+			}
+		}
+	}
+}
+
+func TestNewBlankProblemsExamples(t *testing.T) {
+	mul := NewBlankProblems(problems{{a: 7, b: 8}, {a: 7, b: 8}}, modeMul)
+	if mul[0].question != "? x 8 = 56" || mul[0].answer != intAnswer(7) {
+		t.Errorf("got %q = %s, want ? x 8 = 56 = 7", mul[0].question, mul[0].answer)
+	}
+	if mul[1].question != "7 x ? = 56" || mul[1].answer != intAnswer(8) {
+		t.Errorf("got %q = %s, want 7 x ? = 56 = 8", mul[1].question, mul[1].answer)
+	}
+	div := NewBlankProblems(problems{{a: 7, b: 8}, {a: 7, b: 8}}, modeDiv)
+	if div[0].question != "? / 7 = 8" || div[0].answer != intAnswer(56) || div[1].question != "56 / ? = 8" || div[1].answer != intAnswer(7) {
+		t.Errorf("got %q = %s and %q = %s", div[0].question, div[0].answer, div[1].question, div[1].answer)
+	}
+}
+
+func TestNewBlankProblemsOtherModes(t *testing.T) {
+	p := NewDivRemProblems(7)
+	if got := NewBlankProblems(p, modeDivRem); got[0].question != p[0].question {
+		t.Errorf("got %q, want divr problems left alone", got[0].question)
+	}
+}
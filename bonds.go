@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// drawBond shows a number bond, the whole on top with its two parts below
+func drawBond(whole, a, b int) string {
+	sa, sb := strconv.Itoa(a), strconv.Itoa(b)
+	parts := sa + "    " + sb
+	sw := strconv.Itoa(whole)
+	return strings.Join([]string{
+		strings.Repeat(" ", max(len(parts)-len(sw), 0)/2) + sw,
+		strings.Repeat(" ", len(sa)) + "/  \\",
+		parts,
+	}, "\n")
+}
+
+// NewBondProblems asks for the number that makes 10 or 100, and for the step in adding
+// by bridging through ten, like 38 + 5 = 40 + 3
+func NewBondProblems() problems {
+	var p problems
+	for _, whole := range []int{10, 100} {
+		for a := range whole + 1 {
+			if whole == 100 && (a == 0 || a == 100) {
+				continue // These are easy in make ten already
+			}
+			q := fmt.Sprintf("%d + ? = %d", a, whole)
+			prob := NewProblem(q, intAnswer(whole-a))
+			prob.prompt = q
+			prob.solution = fmt.Sprintf("%d + %d = %d", a, whole-a, whole)
+			prob.steps = drawBond(whole, a, whole-a)
+			p = append(p, prob)
+		}
+	}
+
+	// Bridging through the next ten, splitting b into the part that makes the ten and the rest
+	for tens := 0; tens < 100; tens += 10 {
+		for ones := 6; ones <= 9; ones++ {
+			for b := 11 - ones; b <= 9; b++ {
+				a := tens + ones
+				next, rest := tens+10, b-(10-ones)
+				q := fmt.Sprintf("%d + %d = %d + ?", a, b, next)
+				prob := NewProblem(q, intAnswer(rest))
+				prob.prompt = q
+				prob.solution = fmt.Sprintf("%d + %d = %d + %d = %d", a, b, next, rest, a+b)
+				prob.steps = fmt.Sprintf("Split %d to make %d:\n\n%s\n\n%d + %d = %d, then %d + %d = %d",
+					b, next, drawBond(b, 10-ones, rest), a, 10-ones, next, next, rest, a+b)
+				p = append(p, prob)
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewBondProblems(t *testing.T) {
+	for _, prob := range NewBondProblems() {
+		// The answer goes in the blank, so filling it in starts the solution
+		filled := strings.Replace(prob.question, "?", prob.answer.String(), 1)
+		if !strings.HasPrefix(prob.solution, filled) {
+			t.Errorf("%q with %s filled in is %q, want the start of %q", prob.question, prob.answer, filled, prob.solution)
+		}
+	}
+}
+
+func TestDrawBond(t *testing.T) {
+	want := "  10\n /  \\\n3    7"
+	if got := drawBond(10, 3, 7); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
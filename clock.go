@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// clockTime is a time on a 12 hour clock, in minutes after 12:00
+type clockTime int
+
+func newClockTime(hour, minute int) clockTime {
+	return clockTime(((hour*60+minute)%720 + 720) % 720)
+}
+
+func (t clockTime) hour() int {
+	if h := int(t) / 60; h != 0 {
+		return h
+	}
+	return 12
+}
+
+func (t clockTime) minute() int {
+	return int(t) % 60
+}
+
+func (t clockTime) String() string {
+	return fmt.Sprintf("%d:%02d", t.hour(), t.minute())
+}
+
+// timeAnswer is a time, like "2:30"
+type timeAnswer clockTime
+
+// Parse reads "2:30" or "02:30", and ignores a trailing am or pm
+func (a timeAnswer) Parse(s string) (answer, error) {
+	errFormat := errors.New("please enter a time like 2:30")
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(s, "am"), "pm"))
+	h, mm, found := strings.Cut(s, ":")
+	if !found || len(strings.TrimSpace(mm)) != 2 {
+		return nil, errFormat
+	}
+	hour, err := strconv.Atoi(strings.TrimSpace(h))
+	if err != nil || hour < 0 || hour > 23 {
+		return nil, errFormat
+	}
+	minute, err := strconv.Atoi(strings.TrimSpace(mm))
+	if err != nil || minute < 0 || minute > 59 {
+		return nil, errFormat
+	}
+	return timeAnswer(newClockTime(hour, minute)), nil
+}
+
+func (a timeAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(timeAnswer)
+	switch {
+	case !ok:
+		return false, ""
+	case g == a:
+		return true, ""
+	case clockTime(g).minute() == clockTime(a).minute():
+		return false, "So close, the minutes are right but the hour isn't."
+	case clockTime(g).hour() == clockTime(a).hour():
+		return false, "So close, the hour is right but the minutes aren't."
+	}
+	return false, ""
+}
+
+func (a timeAnswer) String() string {
+	return clockTime(a).String()
+}
+
+// drawClock renders an analog clock face, where the long hand (*) is the minutes
+// and the short hand (#) is the hours. Columns are doubled as characters are tall.
+func drawClock(t clockTime) string {
+	const radius = 5
+	cx, cy := 2*radius+1, radius
+	grid := make([][]rune, 2*radius+1)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", 4*radius+3))
+	}
+
+	// point is where a hand or number goes, at a fraction of a turn and of the radius
+	point := func(turn, length float64) (int, int) {
+		angle := 2 * math.Pi * turn
+		return cx + int(math.Round(2*length*math.Sin(angle))), cy - int(math.Round(length*math.Cos(angle)))
+	}
+	hand := func(turn, length float64, r rune) {
+		for l := 1.0; l <= length; l += 0.5 {
+			x, y := point(turn, l)
+			grid[y][x] = r
+		}
+	}
+	hand(float64(t.minute())/60, radius-1.5, '*')
+	hand(float64(int(t)%720)/720, radius-2.5, '#')
+	grid[cy][cx] = 'o'
+
+	for n := 1; n <= 12; n++ {
+		x, y := point(float64(n)/12, radius)
+		for i, r := range strconv.Itoa(n) {
+			grid[y][x+i-(n/10)] = r // Center 10, 11 and 12 on their spot
+		}
+	}
+
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = string(row)
+	}
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
+
+// minutes formats a number of minutes like "1 hour and 15 minutes"
+func minutes(n int) string {
+	unit := func(n int, name string) string {
+		if n == 1 {
+			return "1 " + singular(name)
+		}
+		return fmt.Sprintf("%d %s", n, name)
+	}
+	h, m := n/60, n%60
+	switch {
+	case h == 0:
+		return unit(m, "minutes")
+	case m == 0:
+		return unit(h, "hours")
+	}
+	return unit(h, "hours") + " and " + unit(m, "minutes")
+}
+
+// NewTimeProblems reads every clock face to five minutes, and makes random elapsed time
+// problems
+func NewTimeProblems() problems {
+	var p problems
+	for t := clockTime(0); t < 720; t += 5 {
+		prob := NewProblem("Read the clock at "+t.String(), timeAnswer(t))
+		prob.prompt = "What time is it?\n\n" + drawClock(t)
+		on := t.minute() / 5
+		if on == 0 {
+			on = 12
+		}
+		prob.solution = fmt.Sprintf("The short hand is at %d and the long hand is on %d, so it's %s", t.hour(), on, t)
+		p = append(p, prob)
+	}
+
+	elapsed := NewSampledProblems(sampleSize, func() problem {
+		start := newClockTime(1+rand.Intn(12), 5*rand.Intn(12))
+		n := 5 * (1 + rand.Intn(36)) // Up to 3 hours
+		switch rand.Intn(3) {
+		case 0:
+			end := newClockTime(0, int(start)+n)
+			q := fmt.Sprintf("What time is %s after %s?", minutes(n), start)
+			prob := NewProblem(q, timeAnswer(end))
+			prob.prompt = q
+			prob.solution = fmt.Sprintf("%s + %s = %s", start, minutes(n), end)
+			return prob
+		case 1:
+			end := newClockTime(0, int(start)-n)
+			q := fmt.Sprintf("What time is %s before %s?", minutes(n), start)
+			prob := NewProblem(q, timeAnswer(end))
+			prob.prompt = q
+			prob.solution = fmt.Sprintf("%s - %s = %s", start, minutes(n), end)
+			return prob
+		}
+		end := newClockTime(0, int(start)+n)
+		q := fmt.Sprintf("How many minutes from %s to %s?", start, end)
+		prob := NewProblem(q, intAnswer(n))
+		prob.prompt = q
+		prob.solution = fmt.Sprintf("From %s to %s is %s, or %d minutes", start, end, minutes(n), n)
+		return prob
+	})
+
+	return append(p, elapsed...)
+}
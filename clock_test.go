@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestTimeAnswer(t *testing.T) {
+	half := timeAnswer(newClockTime(2, 30))
+	checkAnswers(t, []answerTest{
+		{"right", half, "2:30", true, "", false},
+		{"leading zero", half, "02:30", true, "", false},
+		{"24 hour", half, "14:30", true, "", false},
+		{"pm", half, "2:30 pm", true, "", false},
+		{"am", half, "2:30am", true, "", false},
+		{"noon", timeAnswer(newClockTime(12, 0)), "12:00", true, "", false},
+		{"wrong hour", half, "3:30", false, "So close, the minutes are right but the hour isn't.", false},
+		{"wrong minutes", half, "2:35", false, "So close, the hour is right but the minutes aren't.", false},
+		{"both wrong", half, "3:35", false, "", false},
+		{"one digit minutes", half, "2:3", false, "", true},
+		{"no colon", half, "230", false, "", true},
+		{"too many minutes", half, "2:75", false, "", true},
+	})
+	checkRoundTrip(t, half, timeAnswer(newClockTime(12, 5)), timeAnswer(newClockTime(0, 0)))
+}
+
+func TestNewClockTime(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		want         string
+	}{
+		{2, 30, "2:30"},
+		{14, 30, "2:30"},
+		{0, 5, "12:05"},
+		{11, 70, "12:10"},
+		{1, -15, "12:45"},
+	}
+	for _, tt := range tests {
+		if got := newClockTime(tt.hour, tt.minute).String(); got != tt.want {
+			t.Errorf("newClockTime(%d, %d) = %s, want %s", tt.hour, tt.minute, got, tt.want)
+		}
+	}
+}
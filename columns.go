@@ -0,0 +1,173 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	cellStyle   = style.Bold(true)
+	cursorStyle = style.Reverse(true)
+)
+
+// columnInput is for add and sub problems stacked in columns, like on paper. The player
+// types the answer digit by digit from right to left, and can fill in the carries or
+// borrows above each column. Column 0 is the ones.
+type columnInput struct {
+	a, b    int
+	op      mode
+	width   int
+	answer  []rune // Digits typed for the answer, zero when empty
+	marks   []rune // Carries or borrows above each column, zero when empty
+	col     int
+	onMarks bool // The cursor is on the carries or borrows, instead of the answer
+}
+
+func newColumnInput(p problem) columnInput {
+	width := len(strconv.Itoa(max(p.a, p.b, p.a+p.b)))
+	if p.kind == modeSub {
+		width = len(strconv.Itoa(p.a))
+	}
+	return columnInput{
+		a:      p.a,
+		b:      p.b,
+		op:     p.kind,
+		width:  width,
+		answer: make([]rune, width),
+		marks:  make([]rune, width),
+	}
+}
+
+// inColumns is true when the current problem is shown in columns
+func (m model) inColumns() bool {
+	return m.columns && m.prob.prompt == "" && (m.prob.kind == modeAdd || m.prob.kind == modeSub) && max(m.prob.a, m.prob.b) >= 10
+}
+
+// Update types digits and moves between the cells. Typing an answer digit moves left to
+// the next column, and typing a carry drops back down to the answer.
+func (c columnInput) Update(msg tea.KeyMsg) columnInput {
+	cells := c.answer
+	if c.onMarks {
+		cells = c.marks
+	}
+	switch msg.Type {
+	case tea.KeyLeft:
+		c.col = min(c.col+1, c.width-1)
+	case tea.KeyRight:
+		c.col = max(c.col-1, 0)
+		if c.onMarks && c.col == 0 {
+			c.col = 1 // Nothing carries into the ones
+		}
+	case tea.KeyUp:
+		if c.width > 1 {
+			c.onMarks, c.col = true, max(c.col, 1)
+		}
+	case tea.KeyDown:
+		c.onMarks = false
+	case tea.KeyBackspace, tea.KeyDelete:
+		if cells[c.col] == 0 && !c.onMarks {
+			c.col = max(c.col-1, 0)
+		}
+		cells[c.col] = 0
+	case tea.KeyRunes:
+		r := msg.Runes[0]
+		if r < '0' || r > '9' {
+			return c
+		}
+		cells[c.col] = r
+		if c.onMarks {
+			c.onMarks = false
+		} else {
+			c.col = min(c.col+1, c.width-1)
+		}
+	}
+	return c
+}
+
+// Value is the answer typed so far, with a ? for any gaps
+func (c columnInput) Value() string {
+	var b strings.Builder
+	for i := c.width - 1; i >= 0; i-- {
+		switch {
+		case c.answer[i] != 0:
+			b.WriteRune(c.answer[i])
+		case b.Len() > 0:
+			b.WriteRune('?')
+		}
+	}
+	return b.String()
+}
+
+// Check returns a hint when a carry or borrow the player filled in is wrong
+func (c columnInput) Check() string {
+	for i := 1; i < c.width; i++ {
+		if c.marks[i] == 0 {
+			continue
+		}
+		mark := int(c.marks[i] - '0')
+		place := pow10(i)
+		if c.op == modeAdd {
+			carry := 0
+			if c.a%place+c.b%place >= place {
+				carry = 1
+			}
+			if mark != carry {
+				return "Check your carries."
+			}
+			continue
+		}
+		// The top digit is one less when it lends to the column on its right
+		digit := c.a / place % 10
+		if c.a%place < c.b%place {
+			digit = (digit + 9) % 10
+		}
+		if mark != digit {
+			return "Check your borrows."
+		}
+	}
+	return ""
+}
+
+// View stacks the problem in columns, with the cell being typed in highlighted
+func (c columnInput) View() string {
+	cell := func(r rune, empty string, cursor bool) string {
+		s := empty
+		if r != 0 {
+			s = string(r)
+		}
+		if cursor {
+			return " " + cursorStyle.Render(s)
+		}
+		if r == 0 {
+			return " " + dimStyle.Render(s)
+		}
+		return " " + cellStyle.Render(s)
+	}
+	number := func(n int) string {
+		s := strconv.Itoa(n)
+		return strings.Repeat("  ", c.width-len(s)) + " " + strings.Join(strings.Split(s, ""), " ")
+	}
+
+	var marks, answer strings.Builder
+	for i := c.width - 1; i >= 0; i-- {
+		if i == 0 {
+			marks.WriteString("  ")
+		} else {
+			marks.WriteString(cell(c.marks[i], "·", c.onMarks && c.col == i))
+		}
+		answer.WriteString(cell(c.answer[i], "_", !c.onMarks && c.col == i))
+	}
+	sign := "+"
+	if c.op == modeSub {
+		sign = "-"
+	}
+	return strings.Join([]string{
+		"  " + marks.String(),
+		"  " + cellStyle.Render(number(c.a)),
+		sign + " " + cellStyle.Render(number(c.b)),
+		"  " + strings.Repeat("-", 2*c.width+1),
+		"  " + answer.String(),
+	}, "\n")
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeColumns presses each key in turn, with digits typed as runes
+func typeColumns(c columnInput, keys ...any) columnInput {
+	for _, k := range keys {
+		switch k := k.(type) {
+		case rune:
+			c = c.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{k}})
+		case tea.KeyType:
+			c = c.Update(tea.KeyMsg{Type: k})
+		}
+	}
+	return c
+}
+
+func TestColumnInputValue(t *testing.T) {
+	c := newColumnInput(problem{a: 47, b: 38, kind: modeAdd})
+	if c = typeColumns(c, '5', '8'); c.Value() != "85" {
+		t.Errorf("got %q, want the answer typed right to left", c.Value())
+	}
+	c = typeColumns(newColumnInput(problem{a: 47, b: 38, kind: modeAdd}), tea.KeyLeft, '8')
+	if c.Value() != "8?" {
+		t.Errorf("got %q, want a gap for the ones", c.Value())
+	}
+}
+
+func TestColumnInputCheck(t *testing.T) {
+	add := problem{a: 47, b: 38, kind: modeAdd}
+	sub := problem{a: 52, b: 17, kind: modeSub}
+	tests := []struct {
+		name string
+		prob problem
+		keys []any
+		want string
+	}{
+		{"no carries", add, []any{'5', '8'}, ""},
+		{"right carry", add, []any{tea.KeyUp, '1'}, ""},
+		{"wrong carry", add, []any{tea.KeyUp, '0'}, "Check your carries."},
+		{"no borrows", sub, []any{'5', '3'}, ""},
+		{"right borrow", sub, []any{tea.KeyUp, '4'}, ""},
+		{"wrong borrow", sub, []any{tea.KeyUp, '5'}, "Check your borrows."},
+	}
+	for _, tt := range tests {
+		c := typeColumns(newColumnInput(tt.prob), tt.keys...)
+		if got := c.Check(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// compareAnswer is one of <, > or =
+type compareAnswer string
+
+// Parse reads <, > or =, or the words less, greater and equal
+func (a compareAnswer) Parse(s string) (answer, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "<", "less", "less than":
+		return compareAnswer("<"), nil
+	case ">", "greater", "greater than", "more":
+		return compareAnswer(">"), nil
+	case "=", "==", "equal", "equals", "same":
+		return compareAnswer("="), nil
+	}
+	return nil, errors.New("please enter <, > or =")
+}
+
+func (a compareAnswer) Check(given answer) (bool, string) {
+	return given == a, ""
+}
+
+func (a compareAnswer) String() string {
+	return string(a)
+}
+
+// compare returns the sign between a and b
+func compare(a, b fraction) compareAnswer {
+	switch {
+	case a.less(b):
+		return "<"
+	case b.less(a):
+		return ">"
+	}
+	return "="
+}
+
+// orderAnswer is a list of numbers from least to greatest, kept as they were written
+type orderAnswer struct {
+	values []fraction
+	labels []string
+}
+
+// Parse reads fractions or decimals separated by commas or <, like "1/2, 2/3, 3/4"
+func (a orderAnswer) Parse(s string) (answer, error) {
+	var given orderAnswer
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '<' }) {
+		field = strings.TrimSpace(field)
+		var f fraction
+		if strings.Contains(field, "/") {
+			var err error
+			if f, _, err = parseFraction(field); err != nil {
+				return nil, errors.New("please enter the numbers separated by commas, like 1/2, 2/3, 3/4")
+			}
+		} else {
+			d, err := parseDecimal(field)
+			if err != nil {
+				return nil, errors.New("please enter the numbers separated by commas, like 0.2, 0.25, 0.3")
+			}
+			f = newFraction(d.units, pow10(d.places))
+		}
+		given.values = append(given.values, f)
+		given.labels = append(given.labels, field)
+	}
+	return given, nil
+}
+
+func (a orderAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(orderAnswer)
+	switch {
+	case !ok:
+		return false, ""
+	case slices.Equal(g.values, a.values):
+		return true, ""
+	case len(g.values) != len(a.values):
+		return false, fmt.Sprintf("Use all %d numbers, each one once.", len(a.values))
+	}
+	reversed := slices.Clone(g.values)
+	slices.Reverse(reversed)
+	if slices.Equal(reversed, a.values) {
+		return false, "That's greatest to least, try least to greatest."
+	}
+	return false, ""
+}
+
+func (a orderAnswer) String() string {
+	return strings.Join(a.labels, ", ")
+}
+
+// NewCompareProblems compares whole numbers up to digits digits, fractions, decimals and
+// expressions, and orders lists of fractions and decimals
+func NewCompareProblems(digits int) problems {
+	newCompare := func(a, b string, ans compareAnswer) problem {
+		q := a + " ? " + b
+		prob := NewProblem(q, ans)
+		prob.prompt = fmt.Sprintf("Which goes in the middle, <, > or =?\n\n%s  ○  %s", a, b)
+		prob.solution = fmt.Sprintf("%s %s %s", a, ans, b)
+		return prob
+	}
+	randomDecimal := func() decimal {
+		places := 1 + rand.Intn(2)
+		return decimal{units: 1 + rand.Intn(pow10(places)-1), places: places}.normal()
+	}
+
+	numbers := NewSampledProblems(sampleSize/4, func() problem {
+		a := rand.Intn(pow10(digits))
+		b := a
+		if rand.Intn(4) > 0 {
+			// Change one digit, so the numbers are close and the player has to look at each place
+			place := pow10(rand.Intn(max(len(fmt.Sprint(a)), 1)))
+			b = min(max(a+place*(rand.Intn(19)-9), 0), pow10(digits)-1)
+		}
+		return newCompare(commas(a), commas(b), compare(newFraction(a, 1), newFraction(b, 1)))
+	})
+
+	operands := fracOperands()
+	fractions := NewSampledProblems(sampleSize/4, func() problem {
+		a, b := operands[rand.Intn(len(operands))], operands[rand.Intn(len(operands))]
+		bs := b.String()
+		if rand.Intn(4) == 0 && a.num < a.den {
+			// An equivalent fraction, like 1/2 = 2/4
+			n := 2 + rand.Intn(3)
+			b, bs = a, fmt.Sprintf("%d/%d", a.num*n, a.den*n)
+		}
+		return newCompare(a.String(), bs, compare(a, b))
+	})
+
+	decimals := NewSampledProblems(sampleSize/4, func() problem {
+		a, b := randomDecimal(), randomDecimal()
+		return newCompare(a.String(), b.String(), compare(newFraction(a.units, pow10(a.places)), newFraction(b.units, pow10(b.places))))
+	})
+
+	exprs := NewSampledProblems(sampleSize/4, func() problem {
+		for {
+			a, b := randomExpr(basicOps, 1), randomExpr(basicOps, 1)
+			l, lok := a.eval()
+			r, rok := b.eval()
+			if lok && rok {
+				return newCompare(a.String(), b.String(), compare(newFraction(l, 1), newFraction(r, 1)))
+			}
+		}
+	})
+
+	ordering := NewSampledProblems(sampleSize/4, func() problem {
+		n := 3 + rand.Intn(2)
+		var ans orderAnswer
+		for len(ans.values) < n {
+			var f fraction
+			var label string
+			if rand.Intn(2) == 0 {
+				f = operands[rand.Intn(len(operands)/2)] // Just the proper fractions
+				label = f.String()
+			} else {
+				d := randomDecimal()
+				f, label = newFraction(d.units, pow10(d.places)), d.String()
+			}
+			if !slices.Contains(ans.values, f) {
+				ans.values = append(ans.values, f)
+				ans.labels = append(ans.labels, label)
+			}
+		}
+		q := "Order " + ans.String()
+
+		// Sort the labels along with the values for the answer
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortFunc(order, func(i, j int) int {
+			switch compare(ans.values[i], ans.values[j]) {
+			case "<":
+				return -1
+			case ">":
+				return 1
+			}
+			return 0
+		})
+		var sorted orderAnswer
+		for _, i := range order {
+			sorted.values = append(sorted.values, ans.values[i])
+			sorted.labels = append(sorted.labels, ans.labels[i])
+		}
+
+		prob := NewProblem(q, sorted)
+		prob.prompt = "Put these in order from least to greatest, separated by commas:\n\n" + ans.String()
+		prob.solution = strings.Join(sorted.labels, " < ")
+		return prob
+	})
+
+	return slices.Concat(numbers, fractions, decimals, exprs, ordering)
+}
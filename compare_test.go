@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestCompareAnswer(t *testing.T) {
+	checkAnswers(t, []answerTest{
+		{"sign", compareAnswer("<"), "<", true, "", false},
+		{"words", compareAnswer("<"), "less than", true, "", false},
+		{"more", compareAnswer(">"), "More", true, "", false},
+		{"equal", compareAnswer("="), "==", true, "", false},
+		{"wrong", compareAnswer("<"), ">", false, "", false},
+		{"not a sign", compareAnswer("<"), "smaller", false, "", true},
+	})
+	checkRoundTrip(t, compareAnswer("<"), compareAnswer(">"), compareAnswer("="))
+}
+
+func TestOrderAnswer(t *testing.T) {
+	order := orderAnswer{
+		values: []fraction{newFraction(1, 2), newFraction(2, 3), newFraction(3, 4)},
+		labels: []string{"1/2", "2/3", "3/4"},
+	}
+	checkAnswers(t, []answerTest{
+		{"commas", order, "1/2, 2/3, 3/4", true, "", false},
+		{"less than", order, "1/2 < 2/3 < 3/4", true, "", false},
+		{"equal values", order, "0.5, 4/6, 0.75", true, "", false},
+		{"reversed", order, "3/4, 2/3, 1/2", false, "That's greatest to least, try least to greatest.", false},
+		{"missing", order, "1/2, 3/4", false, "Use all 3 numbers, each one once.", false},
+		{"mixed up", order, "2/3, 1/2, 3/4", false, "", false},
+		{"bad fraction", order, "1/2, 2/x, 3/4", false, "", true},
+		{"bad decimal", order, "0.5, 0.6.1", false, "", true},
+	})
+	checkRoundTrip(t, order, orderAnswer{values: []fraction{newFraction(1, 5), newFraction(1, 4)}, labels: []string{"0.2", "1/4"}})
+}
+
+func TestCompare(t *testing.T) {
+	if got := compare(newFraction(1, 2), newFraction(2, 3)); got != "<" {
+		t.Errorf("1/2 ? 2/3 got %s", got)
+	}
+	if got := compare(newFraction(3, 4), newFraction(2, 3)); got != ">" {
+		t.Errorf("3/4 ? 2/3 got %s", got)
+	}
+	if got := compare(newFraction(2, 4), newFraction(1, 2)); got != "=" {
+		t.Errorf("2/4 ? 1/2 got %s", got)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// decimal is an exact decimal number, units / 10^places, so there are no float surprises
+type decimal struct {
+	units  int
+	places int
+}
+
+// normal strips trailing zeros, so equal decimals are also ==
+func (d decimal) normal() decimal {
+	for d.places > 0 && d.units%10 == 0 {
+		d.units /= 10
+		d.places--
+	}
+	return d
+}
+
+func (d decimal) String() string {
+	sign, units := "", d.units
+	if units < 0 {
+		sign, units = "-", -units
+	}
+	s := strconv.Itoa(units)
+	if d.places == 0 {
+		return sign + s
+	}
+	if len(s) <= d.places {
+		s = strings.Repeat("0", d.places-len(s)+1) + s
+	}
+	return sign + s[:len(s)-d.places] + "." + s[len(s)-d.places:]
+}
+
+// parseDecimal reads "0.5", ".5", "12" or "-1.25"
+func parseDecimal(s string) (decimal, error) {
+	errFormat := errors.New("please enter a number like 0.5")
+	s = normalizeMinus(s)
+	sign := 1
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return decimal{}, errFormat
+	}
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return decimal{}, errFormat
+		}
+	}
+	units, err := strconv.Atoi(whole + frac)
+	if err != nil {
+		return decimal{}, errFormat
+	}
+	return decimal{units: sign * units, places: len(frac)}.normal(), nil
+}
+
+// decimalAnswer is compared exactly, so 0.5, .5 and 0.50 are all the same
+type decimalAnswer struct {
+	value decimal
+}
+
+func (a decimalAnswer) Parse(s string) (answer, error) {
+	d, err := parseDecimal(s)
+	if err != nil {
+		return nil, err
+	}
+	return decimalAnswer{value: d}, nil
+}
+
+func (a decimalAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(decimalAnswer)
+	return ok && g.value == a.value.normal(), ""
+}
+
+func (a decimalAnswer) String() string {
+	return a.value.normal().String()
+}
+
+// NewDecimalProblems makes random problems with up to digits whole number digits and
+// places decimal places
+func NewDecimalProblems(ops []mode, digits, places int) problems {
+	limit := pow10(digits + places)
+	random := func() decimal {
+		return decimal{units: 1 + rand.Intn(limit-1), places: places}
+	}
+	var p problems
+	for _, op := range ops {
+		p = append(p, NewSampledProblems(sampleSize, func() problem {
+			a, b := random(), random()
+			var q string
+			var ans decimal
+			switch op {
+			case modeAdd:
+				q, ans = fmt.Sprintf("%s + %s", a.normal(), b.normal()), decimal{units: a.units + b.units, places: places}
+			case modeSub:
+				if b.units > a.units {
+					a, b = b, a // Don't do negative answers
+				}
+				q, ans = fmt.Sprintf("%s - %s", a.normal(), b.normal()), decimal{units: a.units - b.units, places: places}
+			case modeMul:
+				n := 2 + rand.Intn(8) // Times a single digit, so it's mental math
+				q, ans = fmt.Sprintf("%s x %d", a.normal(), n), decimal{units: a.units * n, places: places}
+			case modeDiv:
+				n := 2 + rand.Intn(8) // Dividend is picked so the answer has places decimal places
+				q, ans = fmt.Sprintf("%s / %d", decimal{units: a.units * n, places: places}.normal(), n), a
+			}
+			return NewProblem(q, decimalAnswer{value: ans})
+		})...)
+	}
+	return p
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestDecimalAnswer(t *testing.T) {
+	half := decimalAnswer{decimal{5, 1}}
+	checkAnswers(t, []answerTest{
+		{"right", half, "0.5", true, "", false},
+		{"leading point", half, ".5", true, "", false},
+		{"trailing zero", half, "0.50", true, "", false},
+		{"not normal", decimalAnswer{decimal{50, 2}}, "0.5", true, "", false},
+		{"whole", decimalAnswer{decimal{3, 0}}, "3.0", true, "", false},
+		{"negative", decimalAnswer{decimal{-125, 2}}, "-1.25", true, "", false},
+		{"unicode minus", decimalAnswer{decimal{-125, 2}}, "−1.25", true, "", false},
+		{"wrong", half, "0.05", false, "", false},
+		{"two points", half, "0.5.1", false, "", true},
+		{"just a point", half, ".", false, "", true},
+		{"letters", half, "0.5a", false, "", true},
+	})
+	checkRoundTrip(t, half, decimalAnswer{decimal{50, 2}}, decimalAnswer{decimal{-125, 2}}, decimalAnswer{decimal{7, 3}})
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		d    decimal
+		want string
+	}{
+		{decimal{5, 1}, "0.5"},
+		{decimal{7, 3}, "0.007"},
+		{decimal{-125, 2}, "-1.25"},
+		{decimal{1200, 2}, "12.00"},
+		{decimal{12, 0}, "12"},
+	}
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("%+v got %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
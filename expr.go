@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// exprMax is the biggest any part of an expression can be, so it stays mental math
+const exprMax = 100
+
+// expr is a node in an expression tree
+type expr interface {
+	// eval returns false when the expression isn't nice for kids, like when
+	// dividing leaves a remainder or the answer goes negative
+	eval() (int, bool)
+	prec() int
+	String() string
+}
+
+// numExpr is a number, the leaves of the tree
+type numExpr int
+
+func (n numExpr) eval() (int, bool) { return int(n), true }
+func (n numExpr) prec() int         { return 3 }
+func (n numExpr) String() string    { return strconv.Itoa(int(n)) }
+
+// binaryExpr is an operation on two expressions, where op is one of the basicOps
+type binaryExpr struct {
+	op          mode
+	left, right expr
+}
+
+func (e binaryExpr) eval() (int, bool) {
+	l, ok := e.left.eval()
+	if !ok {
+		return 0, false
+	}
+	r, ok := e.right.eval()
+	if !ok {
+		return 0, false
+	}
+	var v int
+	switch e.op {
+	case modeAdd:
+		v = l + r
+	case modeSub:
+		v = l - r
+	case modeMul:
+		v = l * r
+	case modeDiv:
+		if r == 0 || l%r != 0 {
+			return 0, false
+		}
+		v = l / r
+	}
+	return v, v >= 0 && v <= exprMax
+}
+
+func (e binaryExpr) prec() int {
+	if e.op == modeMul || e.op == modeDiv {
+		return 2
+	}
+	return 1
+}
+
+// String only adds the parentheses needed to keep the order of operations
+func (e binaryExpr) String() string {
+	var b strings.Builder
+	b.WriteString(parenthesize(e.left, e.left.prec() < e.prec()))
+	switch e.op {
+	case modeAdd:
+		b.WriteString(" + ")
+	case modeSub:
+		b.WriteString(" - ")
+	case modeMul:
+		b.WriteString(" x ")
+	case modeDiv:
+		b.WriteString(" / ")
+	}
+	// 8 - (3 - 1) and 8 / (4 / 2) need parentheses, but 8 + (3 - 1) doesn't
+	b.WriteString(parenthesize(e.right, e.right.prec() < e.prec() || (e.right.prec() == e.prec() && (e.op == modeSub || e.op == modeDiv))))
+	return b.String()
+}
+
+func parenthesize(e expr, needed bool) string {
+	if needed {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+// randomExpr builds a tree that is exactly depth operations deep
+func randomExpr(ops []mode, depth int) expr {
+	if depth == 0 {
+		return numExpr(1 + rand.Intn(9))
+	}
+	left, right := randomExpr(ops, depth-1), randomExpr(ops, rand.Intn(depth))
+	if rand.Intn(2) == 0 {
+		left, right = right, left
+	}
+	e := binaryExpr{op: ops[rand.Intn(len(ops))], left: left, right: right}
+
+	// When the right side is just a number, pick one that keeps the answer nice
+	l, ok := e.left.eval()
+	if _, isNum := e.right.(numExpr); !isNum || !ok || l == 0 {
+		return e
+	}
+	switch e.op {
+	case modeSub:
+		e.right = numExpr(1 + rand.Intn(min(l, 9)))
+	case modeDiv:
+		var divisors []int
+		for d := 1; d <= 9; d++ {
+			if l%d == 0 {
+				divisors = append(divisors, d)
+			}
+		}
+		e.right = numExpr(divisors[rand.Intn(len(divisors))])
+	}
+	return e
+}
+
+// NewExprProblems makes random multi-step problems, like "3 + 4 x (2 - 1)". Without
+// parentheses, only expressions that don't need them are used.
+func NewExprProblems(ops []mode, depth int, parens bool) problems {
+	return NewSampledProblems(sampleSize, func() problem {
+		for {
+			e := randomExpr(ops, depth)
+			v, ok := e.eval()
+			q := e.String()
+			if ok && (parens || !strings.Contains(q, "(")) {
+				return NewProblem(q, intAnswer(v))
+			}
+		}
+	})
+}
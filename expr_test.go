@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestBinaryExprString(t *testing.T) {
+	bin := func(op mode, left, right expr) expr { return binaryExpr{op: op, left: left, right: right} }
+	n := func(i int) expr { return numExpr(i) }
+	tests := []struct {
+		e    expr
+		want string
+		val  int
+	}{
+		{bin(modeAdd, n(3), bin(modeMul, n(4), n(2))), "3 + 4 x 2", 11},
+		{bin(modeMul, bin(modeAdd, n(3), n(4)), n(2)), "(3 + 4) x 2", 14},
+		{bin(modeAdd, n(8), bin(modeSub, n(3), n(1))), "8 + 3 - 1", 10},
+		{bin(modeSub, n(8), bin(modeSub, n(3), n(1))), "8 - (3 - 1)", 6},
+		{bin(modeSub, bin(modeSub, n(8), n(3)), n(1)), "8 - 3 - 1", 4},
+		{bin(modeDiv, n(8), bin(modeDiv, n(4), n(2))), "8 / (4 / 2)", 4},
+		{bin(modeMul, n(8), bin(modeDiv, n(4), n(2))), "8 x 4 / 2", 16},
+		{bin(modeDiv, bin(modeMul, n(6), n(4)), bin(modeSub, n(5), n(2))), "6 x 4 / (5 - 2)", 8},
+	}
+	for _, tt := range tests {
+		if got := tt.e.String(); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+		if got, ok := tt.e.eval(); !ok || got != tt.val {
+			t.Errorf("%s = %d, %v, want %d", tt.want, got, ok, tt.val)
+		}
+	}
+}
+
+func TestBinaryExprEvalNotNice(t *testing.T) {
+	for _, e := range []expr{
+		binaryExpr{op: modeDiv, left: numExpr(7), right: numExpr(2)},
+		binaryExpr{op: modeDiv, left: numExpr(7), right: numExpr(0)},
+		binaryExpr{op: modeSub, left: numExpr(2), right: numExpr(7)},
+		binaryExpr{op: modeMul, left: numExpr(20), right: numExpr(9)},
+	} {
+		if v, ok := e.eval(); ok {
+			t.Errorf("%s = %d, want it rejected", e, v)
+		}
+	}
+}
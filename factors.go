@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// factors of n, smallest first
+func factors(n int) []int {
+	var f []int
+	for d := 1; d <= n; d++ {
+		if n%d == 0 {
+			f = append(f, d)
+		}
+	}
+	return f
+}
+
+// factorPairs shows the factors of n as multiplication, like "24 = 1 x 24 = 2 x 12"
+func factorPairs(n int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d", n)
+	for d := 1; d*d <= n; d++ {
+		if n%d == 0 {
+			fmt.Fprintf(&b, " = %d x %d", d, n/d)
+		}
+	}
+	return b.String()
+}
+
+// NewFactorProblems asks about primes and factors for numbers with up to digits digits,
+// plus random GCD and LCM problems
+func NewFactorProblems(digits int) problems {
+	top := pow10(digits) - 1
+
+	var p problems
+	for n := 2; n <= top; n++ {
+		prime := len(factors(n)) == 2
+		q := fmt.Sprintf("Is %d prime?", n)
+		prob := NewProblem(q, yesNoAnswer(prime))
+		prob.prompt = q
+		prob.solution = "no, " + factorPairs(n)
+		if prime {
+			prob.solution = "yes, " + factorPairs(n) + " only"
+		}
+		p = append(p, prob)
+	}
+
+	// Listing factors gets tedious with big numbers, so stop at 100
+	for n := 2; n <= min(top, 100); n++ {
+		q := fmt.Sprintf("What are the factors of %d?", n)
+		prob := NewProblem(q, setAnswer(factors(n)))
+		prob.prompt = q
+		prob.solution = factorPairs(n)
+		p = append(p, prob)
+	}
+
+	// GCD is the common factor times numbers with no common factors, so the GCD isn't always 1
+	p = append(p, NewSampledProblems(sampleSize/2, func() problem {
+		for {
+			g, x, y := 2+rand.Intn(11), 1+rand.Intn(10), 1+rand.Intn(10)
+			if x == y || gcd(x, y) != 1 || g*max(x, y) > top {
+				continue
+			}
+			q := fmt.Sprintf("GCD of %d and %d", g*x, g*y)
+			prob := NewProblem(q, intAnswer(g))
+			prob.prompt = fmt.Sprintf("What is the greatest common divisor (%s)?", q)
+			return prob
+		}
+	})...)
+
+	// LCM of numbers from the times tables
+	p = append(p, NewSampledProblems(sampleSize/2, func() problem {
+		limit := min(top, mathTableEnd+2)
+		for {
+			a, b := 2+rand.Intn(limit-1), 2+rand.Intn(limit-1)
+			if a == b {
+				continue
+			}
+			q := fmt.Sprintf("LCM of %d and %d", a, b)
+			prob := NewProblem(q, intAnswer(a*b/gcd(a, b)))
+			prob.prompt = fmt.Sprintf("What is the least common multiple (%s)?", q)
+			return prob
+		}
+	})...)
+	return p
+}
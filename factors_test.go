@@ -0,0 +1,18 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFactors(t *testing.T) {
+	if got := factors(24); !slices.Equal(got, []int{1, 2, 3, 4, 6, 8, 12, 24}) {
+		t.Errorf("factors(24) = %v", got)
+	}
+	if got := factors(13); !slices.Equal(got, []int{1, 13}) {
+		t.Errorf("factors(13) = %v", got)
+	}
+	if got := factorPairs(36); got != "36 = 1 x 36 = 2 x 18 = 3 x 12 = 4 x 9 = 6 x 6" {
+		t.Errorf("factorPairs(36) = %q", got)
+	}
+}
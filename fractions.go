@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// fracDens are the denominators used for fraction problems, the ones kids see in school
+var fracDens = []int{2, 3, 4, 5, 6, 8, 10, 12}
+
+// fraction is always kept in lowest terms with a positive denominator
+type fraction struct {
+	num int
+	den int
+}
+
+func newFraction(num, den int) fraction {
+	if den < 0 {
+		num, den = -num, -den
+	}
+	g := gcd(num, den)
+	if g == 0 {
+		return fraction{num: 0, den: 1}
+	}
+	return fraction{num: num / g, den: den / g}
+}
+
+func gcd(a, b int) int {
+	a, b = max(a, -a), max(b, -b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func (f fraction) add(g fraction) fraction {
+	return newFraction(f.num*g.den+g.num*f.den, f.den*g.den)
+}
+
+func (f fraction) sub(g fraction) fraction {
+	return newFraction(f.num*g.den-g.num*f.den, f.den*g.den)
+}
+
+func (f fraction) mul(g fraction) fraction {
+	return newFraction(f.num*g.num, f.den*g.den)
+}
+
+func (f fraction) div(g fraction) fraction {
+	return newFraction(f.num*g.den, f.den*g.num)
+}
+
+func (f fraction) less(g fraction) bool {
+	return f.num*g.den < g.num*f.den
+}
+
+// String formats as a mixed number, like "1 1/2"
+func (f fraction) String() string {
+	sign, num := "", f.num
+	if num < 0 {
+		sign, num = "-", -num
+	}
+	whole, rest := num/f.den, num%f.den
+	switch {
+	case rest == 0:
+		return sign + strconv.Itoa(whole)
+	case whole == 0:
+		return fmt.Sprintf("%s%d/%d", sign, rest, f.den)
+	}
+	return fmt.Sprintf("%s%d %d/%d", sign, whole, rest, f.den)
+}
+
+// parseFraction reads "3/4", "1 1/2", "6/8" or "2", and returns true when it was
+// written in lowest terms
+func parseFraction(s string) (fraction, bool, error) {
+	errFormat := errors.New("please enter a fraction like 3/4 or 1 1/2")
+	s = normalizeMinus(s)
+	sign := 1
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	}
+	whole, frac := "0", s
+	if fields := strings.Fields(s); len(fields) == 2 {
+		whole, frac = fields[0], fields[1]
+	} else if len(fields) != 1 {
+		return fraction{}, false, errFormat
+	}
+	w, err := strconv.Atoi(whole)
+	if err != nil || w < 0 {
+		return fraction{}, false, errFormat
+	}
+	n, d, found := strings.Cut(frac, "/")
+	if !found {
+		d = "1"
+	}
+	num, err := strconv.Atoi(strings.TrimSpace(n))
+	if err != nil || num < 0 {
+		return fraction{}, false, errFormat
+	}
+	den, err := strconv.Atoi(strings.TrimSpace(d))
+	if err != nil || den <= 0 {
+		return fraction{}, false, errFormat
+	}
+	return newFraction(sign*(w*den+num), den), gcd(num, den) == 1 || num == 0, nil
+}
+
+// fracAnswer accepts any equal fraction, unless it has to be simplified
+type fracAnswer struct {
+	value    fraction
+	simplest bool // The player wrote the answer in lowest terms
+	simplify bool // The player must write the answer in lowest terms
+}
+
+func (a fracAnswer) Parse(s string) (answer, error) {
+	f, simplest, err := parseFraction(s)
+	if err != nil {
+		return nil, err
+	}
+	return fracAnswer{value: f, simplest: simplest}, nil
+}
+
+func (a fracAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(fracAnswer)
+	switch {
+	case !ok || g.value != a.value:
+		return false, ""
+	case a.simplify && !g.simplest:
+		return false, "That's the right amount, but it can be simplified!"
+	}
+	return true, ""
+}
+
+func (a fracAnswer) String() string {
+	return a.value.String()
+}
+
+// fracOperands are every proper fraction in lowest terms using fracDens, plus one more
+// than each of them as mixed numbers
+func fracOperands() []fraction {
+	var out []fraction
+	for whole := range 2 {
+		for _, den := range fracDens {
+			for num := 1; num < den; num++ {
+				if gcd(num, den) == 1 {
+					out = append(out, newFraction(whole*den+num, den))
+				}
+			}
+		}
+	}
+	return out
+}
+
+func NewFracProblems(ops []mode, simplify bool) problems {
+	operands := fracOperands()
+
+	var p problems
+	for _, op := range ops {
+		for _, a := range operands {
+			for _, b := range operands {
+				var q string
+				var ans fraction
+				switch op {
+				case modeAdd:
+					q, ans = fmt.Sprintf("%s + %s", a, b), a.add(b)
+				case modeSub:
+					if !b.less(a) {
+						continue // Don't do negative answers
+					}
+					q, ans = fmt.Sprintf("%s - %s", a, b), a.sub(b)
+				case modeMul:
+					q, ans = fmt.Sprintf("%s x %s", a, b), a.mul(b)
+				case modeDiv:
+					q, ans = fmt.Sprintf("%s ÷ %s", a, b), a.div(b)
+				}
+				p = append(p, NewProblem(q, fracAnswer{value: ans, simplify: simplify}))
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestFracAnswer(t *testing.T) {
+	half := fracAnswer{value: newFraction(3, 2)}
+	simplify := fracAnswer{value: newFraction(3, 2), simplify: true}
+	checkAnswers(t, []answerTest{
+		{"mixed", half, "1 1/2", true, "", false},
+		{"improper", half, "3/2", true, "", false},
+		{"equal", half, "6/4", true, "", false},
+		{"whole", fracAnswer{value: newFraction(2, 1)}, "2", true, "", false},
+		{"negative", fracAnswer{value: newFraction(-3, 4)}, "-3/4", true, "", false},
+		{"unicode minus", fracAnswer{value: newFraction(-3, 2)}, "−1 1/2", true, "", false},
+		{"simplified", simplify, "1 1/2", true, "", false},
+		{"not simplified", simplify, "6/4", false, "That's the right amount, but it can be simplified!", false},
+		{"not simplified mixed", simplify, "1 2/4", false, "That's the right amount, but it can be simplified!", false},
+		{"wrong", simplify, "5/4", false, "", false},
+		{"zero denominator", half, "3/0", false, "", true},
+		{"not a fraction", half, "1 1 1/2", false, "", true},
+	})
+	checkRoundTrip(t, half, simplify, fracAnswer{value: newFraction(-3, 4)}, fracAnswer{value: newFraction(5, 1)})
+}
+
+func TestFractionString(t *testing.T) {
+	tests := []struct {
+		f    fraction
+		want string
+	}{
+		{newFraction(6, 4), "1 1/2"},
+		{newFraction(2, 4), "1/2"},
+		{newFraction(8, 4), "2"},
+		{newFraction(-5, 4), "-1 1/4"},
+		{newFraction(3, -4), "-3/4"},
+		{newFraction(0, 5), "0"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.want {
+			t.Errorf("%d/%d got %q, want %q", tt.f.num, tt.f.den, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// heatmapCell is the combined counters for one fact, as several problems can share a fact
+type heatmapCell struct {
+	correct int
+	wrong   int
+	latency time.Duration // Total time, not the average
+	seen    int
+}
+
+var (
+	heatmapWrong, _ = colorful.Hex("#ff0000")
+	heatmapRight, _ = colorful.Hex("#1ac500")
+	heatmapBg, _    = colorful.Hex(string(bgColor))
+	heatmapUnseen   = style.Background(lipgloss.Color("240"))
+)
+
+// heatmap renders the times table as a grid, each cell colored from red to green by how
+// often the fact was answered correctly and faded out the slower it was answered
+func heatmap(title string, probs problems) string {
+	var cells [mathTableEnd + 1][mathTableEnd + 1]heatmapCell
+	for _, p := range probs {
+		if p.a < 1 || p.a > mathTableEnd || p.b < 1 || p.b > mathTableEnd || p.seen == 0 {
+			continue
+		}
+		c := &cells[p.a][p.b]
+		c.correct += p.correct
+		c.wrong += p.wrong
+		c.latency += p.latency * time.Duration(p.seen)
+		c.seen += p.seen
+	}
+
+	var b strings.Builder
+	b.WriteString(rainbow(style.Bold(true), title, blends) + "\n\n")
+	b.WriteString(style.Bold(true).Render("   x"))
+	for col := 1; col <= mathTableEnd; col++ {
+		b.WriteString(style.Bold(true).Render(fmt.Sprintf("%4d", col)))
+	}
+	b.WriteString("\n")
+	for row := 1; row <= mathTableEnd; row++ {
+		b.WriteString(style.Bold(true).Render(fmt.Sprintf("%4d", row)))
+		for col := 1; col <= mathTableEnd; col++ {
+			b.WriteString(style.Render(" ") + cells[row][col].style().Render(fmt.Sprintf("%3d", row*col)))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (c heatmapCell) style() lipgloss.Style {
+	if c.correct+c.wrong == 0 {
+		return heatmapUnseen
+	}
+	accuracy := float64(c.correct) / float64(c.correct+c.wrong)
+
+	// Quick answers get the full color, fading out as answers get slower
+	avg := c.latency / time.Duration(c.seen)
+	speed := 1 - min(max(float64(avg-fluentLatency/2)/float64(fluentLatency*3), 0), 1)
+
+	bg := heatmapBg.BlendLab(heatmapWrong.BlendLab(heatmapRight, accuracy), 0.35+0.65*speed)
+	return style.Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color(bg.Clamped().Hex()))
+}
+
+// heatmapTitle for the mode, or empty when the mode doesn't have a heatmap
+func heatmapTitle(md mode) string {
+	switch md {
+	case modeMul:
+		return "Multiplication facts"
+	case modeDiv:
+		return "Division facts, by divisor and answer"
+	}
+	return ""
+}
+
+// hasHeatmap returns true when any of the modes being played has a heatmap
+func (m model) hasHeatmap() bool {
+	for _, md := range m.modes() {
+		if heatmapTitle(md) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// heatmapView renders a heatmap for each mode being played, side by side
+func (m model) heatmapView() string {
+	var maps []string
+	for _, md := range m.modes() {
+		title := heatmapTitle(md)
+		if title == "" {
+			continue
+		}
+		var probs problems
+		for _, p := range m.probs {
+			if p.kind == md {
+				probs = append(probs, p)
+			}
+		}
+		if len(maps) > 0 {
+			maps = append(maps, style.Render("    "))
+		}
+		maps = append(maps, heatmap(title, probs))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, maps...) +
+		"\n" + dimStyle.Render("Green is right, red is wrong, darker is slower and grey is not practiced yet.")
+}
+
+// heatmapHelp is added to the help text when there is a heatmap to show
+func (m model) heatmapHelp() string {
+	if !m.hasHeatmap() {
+		return ""
+	}
+	return " or tab to see your facts"
+}
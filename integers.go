@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// signed formats negative numbers in brackets, so "5 - -3" reads as "5 - (-3)"
+func signed(n int) string {
+	if n < 0 {
+		return fmt.Sprintf("(%d)", n)
+	}
+	return strconv.Itoa(n)
+}
+
+// NewIntegerProblems uses negative and positive numbers with up to digits digits. One digit
+// numbers are all listed, bigger ones are random.
+func NewIntegerProblems(ops []mode, digits int) problems {
+	limit := pow10(digits) - 1
+	newProblem := func(op mode, a, b int) (problem, bool) {
+		switch op {
+		case modeAdd:
+			return NewProblem(fmt.Sprintf("%d + %s", a, signed(b)), intAnswer(a+b)), true
+		case modeSub:
+			return NewProblem(fmt.Sprintf("%d - %s", a, signed(b)), intAnswer(a-b)), true
+		case modeMul:
+			return NewProblem(fmt.Sprintf("%d x %s", a, signed(b)), intAnswer(a*b)), true
+		case modeDiv:
+			if b == 0 {
+				return problem{}, false
+			}
+			return NewProblem(fmt.Sprintf("%d / %s", a*b, signed(b)), intAnswer(a)), true // Dividend is picked so it divides evenly
+		}
+		return problem{}, false
+	}
+
+	var p problems
+	for _, op := range ops {
+		if digits > 1 {
+			p = append(p, NewSampledProblems(sampleSize, func() problem {
+				for {
+					if prob, ok := newProblem(op, rand.Intn(limit*2+1)-limit, rand.Intn(limit*2+1)-limit); ok {
+						return prob
+					}
+				}
+			})...)
+			continue
+		}
+		for a := -limit; a <= limit; a++ {
+			for b := -limit; b <= limit; b++ {
+				if prob, ok := newProblem(op, a, b); ok {
+					p = append(p, prob)
+				}
+			}
+		}
+	}
+	return p
+}
@@ -9,6 +9,7 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -16,12 +17,15 @@ import (
 	_ "embed"
 
 	cowsay "github.com/Code-Hex/Neo-cowsay/v2"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/stopwatch"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/ebitengine/oto/v3"
 	"github.com/hajimehoshi/go-mp3"
 	"github.com/lucasb-eyer/go-colorful"
@@ -79,20 +83,76 @@ const (
 	modeSub
 	modeMul
 	modeDiv
+	modeMixed
+	modeDivRem
+	modeFrac
+	modeDecimal
+	modeInt
+	modeExpr
+	modeWords
+	modePowers
+	modeFactors
+	modePlace
+	modeMoney
+	modeTime
+	modeUnits
+	modeCompare
+	modeSequence
+	modeAlgebra
+	modePercent
 )
 
 type problem struct {
 	question string
-	answer   int
+	answer   answer
+	prompt   string // What the player is asked, when it's not just "question = ?"
+	solution string // The question with the answer, when it's not just "question = answer"
+	steps    string // How to work out the answer, shown with the feedback
+	kind     mode
+	a, b     int  // The fact behind the question, for mul and div this is a x b
+	sampled  bool // Made at random, so it likely won't be asked again next time
 	seen     int
 	correct  int
 	wrong    int
+	lastSeen time.Time
+	latency  time.Duration // Average time taken to answer
+
+	// Scheduling state, see scheduler
+	box      int
+	ease     float64
+	reps     int
+	interval time.Duration
+	due      time.Time
 }
 
-func NewProblem(question string, answer int) problem {
+func NewProblem(question string, answer answer) problem {
 	return problem{question: question, answer: answer}
 }
 
+// Prompt is what the player is asked, like "7 x 8 = ?"
+func (p problem) Prompt() string {
+	if p.prompt != "" {
+		return p.prompt
+	}
+	return p.question + " = ?"
+}
+
+// Solution is the question with the answer filled in, like "7 x 8 = 56"
+func (p problem) Solution() string {
+	if p.solution != "" {
+		return p.solution
+	}
+	return fmt.Sprintf("%s = %s", p.question, p.answer)
+}
+
+// Explain adds the steps to work out the answer to the feedback message, if there are any
+func (p problem) Explain(message string) string {
+	if p.steps == "" {
+		return message
+	}
+	return message + "\n\n" + p.steps
+}
+
 type problems []problem
 
 func NewMulProblems(table int) problems {
@@ -104,7 +164,9 @@ func NewMulProblems(table int) problems {
 		return p
 	}
 	for x := 1; x <= mathTableEnd; x++ {
-		p = append(p, NewProblem(fmt.Sprintf("%d x %d", table, x), table*x))
+		prob := NewProblem(fmt.Sprintf("%d x %d", table, x), intAnswer(table*x))
+		prob.a, prob.b = table, x
+		p = append(p, prob)
 	}
 	return p
 }
@@ -118,7 +180,25 @@ func NewDivProblems(table int) problems {
 		return p
 	}
 	for x := 1; x <= mathTableEnd; x++ {
-		p = append(p, NewProblem(fmt.Sprintf("%d / %d", x*table, table), x))
+		prob := NewProblem(fmt.Sprintf("%d / %d", x*table, table), intAnswer(x))
+		prob.a, prob.b = table, x
+		p = append(p, prob)
+	}
+	return p
+}
+
+func NewDivRemProblems(table int) problems {
+	var p problems
+	if table == 0 {
+		for y := 2; y <= mathTableEnd; y++ {
+			p = append(p, NewDivRemProblems(y)...)
+		}
+		return p
+	}
+	for x := 1; x <= mathTableEnd; x++ {
+		for r := 1; r < table; r++ { // Leave out r = 0, those are just div
+			p = append(p, NewProblem(fmt.Sprintf("%d / %d", x*table+r, table), remainderAnswer{quotient: x, remainder: r}))
+		}
 	}
 	return p
 }
@@ -130,7 +210,7 @@ func NewAddProblems(digits int) problems {
 	var p problems
 	for a := 1; a < max; a++ {
 		for b := 1; b < max; b++ {
-			p = append(p, problem{question: fmt.Sprintf("%d + %d", a, b), answer: a + b})
+			p = append(p, problem{question: fmt.Sprintf("%d + %d", a, b), answer: intAnswer(a + b), a: a, b: b})
 		}
 	}
 	return p
@@ -145,8 +225,28 @@ func NewSubProblems(digits int) problems {
 			if b > a {
 				break // Don't do negative answers yet
 			}
-			p = append(p, problem{question: fmt.Sprintf("%d - %d", a, b), answer: a - b})
+			p = append(p, problem{question: fmt.Sprintf("%d - %d", a, b), answer: intAnswer(a - b), a: a, b: b})
+		}
+	}
+	return p
+}
+
+// sampleSize is how many problems NewSampledProblems makes for a mode
+const sampleSize = 200
+
+// NewSampledProblems makes up to n problems by calling gen, skipping any repeated questions.
+// It's for modes with too many problems to list them all, so they get a random sample.
+func NewSampledProblems(n int, gen func() problem) problems {
+	seen := make(map[string]bool)
+	var p problems
+	for tries := 0; len(p) < n && tries < n*10; tries++ {
+		prob := gen()
+		if seen[prob.question] {
+			continue
 		}
+		seen[prob.question] = true
+		prob.sampled = true
+		p = append(p, prob)
 	}
 	return p
 }
@@ -179,7 +279,7 @@ func (p problems) Random() problem {
 
 func (p problems) IndexOf(a problem) int {
 	for i, prob := range p {
-		if prob.question == a.question {
+		if prob.question == a.question && prob.kind == a.kind {
 			return i
 		}
 	}
@@ -213,21 +313,43 @@ func NewCoach(h map[string]int) string {
 type model struct {
 	screen       screen
 	mode         mode
+	mix          map[mode]int // Modes to mix together and their weights, for modeMixed
 	player       string
 	digits       int
+	places       int // Decimal places, for modeDecimal
 	table        int
+	length       int    // How many numbers to show in a sequence, for modeSequence
+	fractional   bool   // Allow x to be a fraction, for modeAlgebra
+	ops          []mode // Operations to practice, for modes like modeFrac
+	simplify     bool   // Fraction answers must be in lowest terms
+	depth        int    // Operations deep, for modeExpr
+	parens       bool   // Allow parentheses, for modeExpr
+	blanks       bool   // Fill in the blank problems, for modeAdd, modeSub, modeMul and modeDiv
+	bonds        bool   // Number bonds and making ten instead of every sum, for modeAdd
+	columns      bool   // Stack multi-digit problems in columns, for modeAdd and modeSub
+	words        wordBank
+	units        unitTable
+	limit        int  // Biggest number to square or square root, for modePowers
+	caret        bool // Show powers like 7^2 instead of 7², for modePowers
+	currency     string
 	input        textinput.Model
+	column       columnInput // Used instead of input when the problem is in columns
 	feedback     string
 	prob         problem
 	probs        problems
+	sched        scheduler
+	asked        time.Time // When the current problem was first shown
+	paused       time.Time // When the heatmap was opened during play, so it isn't counted as time to answer
 	coach        string
 	coachHist    map[string]int
 	level        int
 	levelBar     progress.Model
 	stopwatch    stopwatch.Model
+	report       viewport.Model
 	windowWidth  int
 	windowHeight int
 	splashWait   int
+	heatmap      bool // Show the heatmap instead of the current screen
 
 	otoContext *oto.Context
 
@@ -236,6 +358,7 @@ type model struct {
 	totalWrong int
 	rightMap   map[string]int
 	wrongMap   map[string]int
+	slowMap    map[string]time.Duration // Slowest answer for each question
 }
 
 func initialModel() model {
@@ -260,6 +383,10 @@ func initialModel() model {
 	return model{
 		screen:     screenSplash,
 		splashWait: 3,
+		sched:      leitnerScheduler{boxes: 5},
+		depth:      2,
+		currency:   "$",
+		parens:     true,
 		level:      1,
 		levelBar:   progress.New(progress.WithDefaultGradient(), progress.WithSpringOptions(15, 0.5), progress.WithoutPercentage()),
 		stopwatch:  stopwatch.NewWithInterval(time.Second),
@@ -267,6 +394,7 @@ func initialModel() model {
 		coachHist:  coachHist,
 		rightMap:   make(map[string]int),
 		wrongMap:   make(map[string]int),
+		slowMap:    make(map[string]time.Duration),
 	}
 }
 
@@ -312,15 +440,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c":
 			return m, tea.Quit
 		case "esc":
-			m.screen = screenEnd
-			return m, tea.Tick(time.Second*3, func(time.Time) tea.Msg { return tea.Quit() })
+			if m.screen == screenEnd {
+				return m, tea.Quit
+			}
+			return m.endGame()
+		case "tab":
+			if m.hasHeatmap() && (m.screen == screenPlay || m.screen == screenEnd) {
+				m.heatmap = !m.heatmap
+				if m.screen == screenPlay {
+					if m.heatmap {
+						m.paused = time.Now()
+					} else {
+						m.asked = m.asked.Add(time.Since(m.paused))
+					}
+				}
+				return m, nil
+			}
+		}
+		if m.heatmap && m.screen == screenPlay {
+			return m, nil // Don't type answers that can't be seen
 		}
 		switch m.screen {
+		case screenEnd:
+			// Scroll the report, or quit on any other key
+			km := m.report.KeyMap
+			if key.Matches(msg, km.Up, km.Down, km.PageUp, km.PageDown) {
+				var cmd tea.Cmd
+				m.report, cmd = m.report.Update(msg)
+				return m, cmd
+			}
+			return m, tea.Quit
 		case screenPlay:
 			switch msg.Type {
 			case tea.KeyEnter:
 				var cmds []tea.Cmd
 				val := strings.TrimSpace(m.input.Value())
+				if m.inColumns() {
+					val = m.column.Value()
+				}
 				if val == "" {
 					return m, nil
 				}
@@ -332,12 +489,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				lval := strings.ToLower(val)
 				if lval == "done" || lval == "quit" || lval == "exit" || lval == "stop" {
-					m.screen = screenEnd
-					return m, tea.Tick(time.Second*3, func(time.Time) tea.Msg { return tea.Quit() })
+					return m.endGame()
 				}
-				ans, err := strconv.Atoi(val)
+				given, err := m.prob.answer.Parse(val)
 				if err == nil {
-					if ans == m.prob.answer {
+					correct, hint := m.prob.answer.Check(given)
+					if correct {
 						m.totalRight++
 						m.rightMap[m.prob.question]++
 						m.prob.correct++
@@ -358,22 +515,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							cmds = append(cmds, PlaySoundCmd(m.otoContext, SoundRight))
 						}
 						cmds = append(cmds, m.levelBar.SetPercent(per))
-						m.feedback = rainbow(style, feedbackCoach(m.coach, fmt.Sprintf("Great job! %s = %d ✅", m.prob.question, m.prob.answer)), correctBlends)
-						// m.feedback = Lolcatize(feedbackCoach(m.coach, fmt.Sprintf("Great job! %s = %d ✅", m.prob.question, m.prob.answer)))
+						m.feedback = rainbow(style, feedbackCoach(m.coach, m.prob.Explain(fmt.Sprintf("Great job! %s ✅", m.prob.Solution()))), correctBlends)
+						// m.feedback = Lolcatize(feedbackCoach(m.coach, fmt.Sprintf("Great job! %s ✅", m.prob.Solution())))
 					} else {
-						m.feedback = rainbow(style, feedbackCoach("dragon-and-cow", fmt.Sprintf("Nice try! The answer is %s = %d", m.prob.question, m.prob.answer)), incorrectBlends)
+						if hint == "" && m.inColumns() {
+							hint = m.column.Check()
+						}
+						if hint == "" {
+							hint = "Nice try!"
+						}
+						m.feedback = rainbow(style, feedbackCoach("dragon-and-cow", m.prob.Explain(fmt.Sprintf("%s The answer is %s", hint, m.prob.Solution()))), incorrectBlends)
 						m.totalWrong++
 						m.wrongMap[m.prob.question]++
 						m.prob.wrong++
 						cmds = append(cmds, PlaySoundCmd(m.otoContext, SoundWrong))
 					}
+					now := time.Now()
+					latency := now.Sub(m.asked)
+					m.slowMap[m.prob.question] = max(m.slowMap[m.prob.question], latency)
+					m.prob.latency = (m.prob.latency*time.Duration(m.prob.seen) + latency) / time.Duration(m.prob.seen+1)
 					m.prob.seen++
+					m.prob.lastSeen = now
+					m.prob = m.sched.Review(m.prob, correct, latency, now)
 					if i := m.probs.IndexOf(m.prob); i >= 0 {
 						m.probs[i] = m.prob
 					}
-					m.prob = m.probs.Random()
+					m.prob = m.sched.Next(m.probs, m.prob, now)
+					m.column = newColumnInput(m.prob)
+					m.asked = now
 				} else {
-					m.feedback = feedbackStyle.Render("Please enter a number!")
+					m.feedback = feedbackStyle.Render(fmt.Sprintf("Oops, %s!", err))
 				}
 				var cmd tea.Cmd
 				if len(cmds) > 0 {
@@ -381,6 +552,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, cmd
 			default:
+				if m.inColumns() {
+					m.column = m.column.Update(msg)
+					return m, nil
+				}
 				var cmd tea.Cmd
 				m.input, cmd = m.input.Update(msg)
 				return m, cmd
@@ -391,7 +566,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case screenSplash:
 			if msg == "next" {
 				m.screen = screenPlay
-				m.prob = m.probs.Random()
+				m.prob = m.sched.Next(m.probs, problem{}, time.Now())
+				m.column = newColumnInput(m.prob)
+				m.asked = time.Now()
 				m.input.SetValue("")
 				m.input.Placeholder = "Your answer"
 				m.input.Focus()
@@ -400,6 +577,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case screenLevelUp:
 			if msg == "next" {
 				m.screen = screenPlay
+				m.asked = time.Now()               // Start timing once the question can be seen
 				return m, m.levelBar.SetPercent(0) // Reset level up bar
 			}
 		}
@@ -407,6 +585,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		padding := 7
 		m.levelBar.Width = msg.Width - padding*2 - 4
 		m.windowWidth, m.windowHeight = msg.Width, msg.Height
+		m.report.Width, m.report.Height = msg.Width-4, m.reportHeight()
 		return m, nil
 	case progress.FrameMsg: // FrameMsg is sent when the progress bar wants to animate itself
 		progressModel, cmd := m.levelBar.Update(msg)
@@ -430,12 +609,18 @@ func (m model) View() string {
 	case screenSplash:
 		o = funMessage(fmt.Sprintf("Welcome, %s!\nLet's play a game :)", m.player), m.windowWidth)
 	case screenPlay:
-		o = "\n" + rainbow(style.Bold(true), fmt.Sprintf("Question: %s = ?", m.prob.question), blends) +
-			"\n\n" + m.input.View() +
+		question := rainbowLines(style.Bold(true), wrap(fmt.Sprintf("Question: %s", m.prob.Prompt()), m.windowWidth-6), blends) +
+			"\n\n" + m.input.View()
+		if m.inColumns() {
+			question = rainbow(style.Bold(true), "Question:", blends) +
+				"\n\n" + m.column.View() +
+				"\n\n" + dimStyle.Render("Type the answer from right to left, press up to carry or borrow.")
+		}
+		o = "\n" + question +
 			"\n\n" + lipgloss.PlaceHorizontal(m.windowWidth, lipgloss.Center, style.Align(lipgloss.Left).Render(m.feedback)) +
 			"\n\n" + rainbow(style.Bold(true), fmt.Sprintf("/// Level %d ", m.level), blends) + m.levelBar.View() +
 			"\n\n" + style.Align(lipgloss.Right).Width(m.windowWidth-6).Render(playtime(m.stopwatch.Elapsed())) +
-			"\n\n\n" + dimStyle.Render("Psst, press the esc key to stop playing.")
+			"\n\n\n" + dimStyle.Render("Psst, press the esc key to stop playing"+m.heatmapHelp()+".")
 
 	case screenLevelUp:
 		l := `
@@ -452,7 +637,13 @@ func (m model) View() string {
 			"\n\n" + rainbow(style.Bold(true), fmt.Sprintf("/// Level %d ", m.level), blends) + m.levelBar.View()
 
 	case screenEnd:
-		o = funMessage(fmt.Sprintf("Thanks for playing, %s!\n", m.player), m.windowWidth)
+		o = funMessage(fmt.Sprintf("Thanks for playing, %s!\n", m.player), m.windowWidth) +
+			"\n" + m.report.View() +
+			"\n\n" + dimStyle.Render("Use the arrow keys to scroll"+m.heatmapHelp()+", press any other key to quit.")
+	}
+	if m.heatmap {
+		o = "\n" + m.heatmapView() +
+			"\n\n" + dimStyle.Render("Press the tab key to go back.")
 	}
 	return appStyle.Width(m.windowWidth).Height(m.windowHeight).Render(o)
 }
@@ -463,18 +654,27 @@ func main() {
 		m = runNewGameForm(m)
 	}
 
-	switch m.mode {
-	case modeMul:
-		m.probs = NewMulProblems(m.table)
-	case modeDiv:
-		m.probs = NewDivProblems(m.table)
-	case modeAdd:
-		m.probs = NewAddProblems(m.digits)
-	case modeSub:
-		m.probs = NewSubProblems(m.digits)
-	default:
-		panic("forgot to implment problems for new game mode")
+	m.probs = m.newProblems()
+	if len(m.probs) == 0 {
+		fmt.Println("Error: there are no problems to practice, try picking more operations")
+		os.Exit(1)
+	}
+	if m.mode == modeMixed {
+		m.sched = mixedScheduler{scheduler: m.sched, weights: m.mix}
+	}
+
+	// Load the player's history before the first problem is picked
+	path, err := profilePath()
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
 	}
+	store, err := loadProfiles(path)
+	if err != nil {
+		fmt.Println("Error: unable to load player profiles -", err)
+		os.Exit(1)
+	}
+	store.Get(m.player).Apply(m.probs)
 
 	// Uncomment to debug problem generation
 	// for _, p := range m.probs {
@@ -483,38 +683,122 @@ func main() {
 	// os.Exit(0)
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		fmt.Println("Error:", err)
 		os.Exit(1)
 	}
+
+	// Quitting, either from screenEnd or ctrl+c, so remember how the player did
+	store.Record(final.(model))
+	if err := store.Save(); err != nil {
+		fmt.Println("Error: unable to save player profile -", err)
+		os.Exit(1)
+	}
 }
 
 func parseFlags(m model) model {
 	opts := struct {
-		Player   string
-		Digits   int
-		Table    int
-		Mode     int
-		Quick    bool
-		NoSounds bool
+		Player     string
+		Digits     int
+		Places     int
+		Table      int
+		Mode       int
+		Mix        string
+		Ops        string
+		Simplify   bool
+		Depth      int
+		Parens     bool
+		Blanks     bool
+		Bonds      bool
+		Columns    bool
+		Words      string
+		Units      string
+		Limit      int
+		Length     int
+		Fractional bool
+		Caret      bool
+		Currency   string
+		Quick      bool
+		NoSounds   bool
+		Scheduler  string
 	}{}
 	flag.StringVar(&opts.Player, "player", "", "Player name")
-	flag.IntVar(&opts.Mode, "mode", 0, fmt.Sprintf("Game mode, add=%d, sub=%d, mul=%d, and div=%d", modeAdd, modeSub, modeMul, modeDiv))
-	flag.IntVar(&opts.Digits, "digits", 0, "For sub/add, max number of digits to use")
-	flag.IntVar(&opts.Table, "table", 0, "For mul, multiplication table to practice, or zero for all")
+	flag.IntVar(&opts.Mode, "mode", 0, modeHelp())
+	flag.StringVar(&opts.Mix, "mix", "", "For mixed, modes to mix and their weights, like add:2,mul:1")
+	flag.IntVar(&opts.Digits, "digits", 0, "For "+modesUsing(optDigits)+", max number of whole number digits to use")
+	flag.IntVar(&opts.Places, "places", 1, "For "+modesUsing(optPlaces)+", number of decimal places to use")
+	flag.StringVar(&opts.Ops, "ops", "", "For "+modesUsing(optOps)+", operations to practice, like add,sub, or empty for all")
+	flag.BoolVar(&opts.Simplify, "simplify", false, "For "+modesUsing(optSimplify)+", answers must be simplified")
+	flag.IntVar(&opts.Depth, "depth", 2, "For "+modesUsing(optDepth)+", how many operations deep expressions are, 1 through 3")
+	flag.BoolVar(&opts.Parens, "parens", true, "For "+modesUsing(optDepth)+", allow parentheses in expressions")
+	flag.BoolVar(&opts.Blanks, "blanks", false, "For "+modesUsing(optBlanks)+", hide a number instead of the answer, like 7 x ? = 56")
+	flag.BoolVar(&opts.Bonds, "bonds", false, "For "+modesUsing(optBonds)+", practice number bonds that make 10 or 100 and adding by making ten")
+	flag.BoolVar(&opts.Columns, "columns", false, "For "+modesUsing(optColumns)+", stack numbers with 2 or more digits in columns and enter the answer one digit at a time")
+	flag.StringVar(&opts.Words, "words", "", "For words, a JSON file of word problem templates to add, see templates/word-problems.json")
+	flag.StringVar(&opts.Units, "units", "", "For units, a JSON file of unit conversions to add, see templates/units.json")
+	flag.IntVar(&opts.Limit, "limit", 12, "For "+modesUsing(optLimit)+", biggest number to square or square root, 1 through 30")
+	flag.BoolVar(&opts.Caret, "caret", false, "For "+modesUsing(optLimit)+", show powers like 7^2 instead of 7²")
+	flag.StringVar(&opts.Currency, "currency", "$", "For "+modesUsing(optCurrency)+", the currency symbol")
+	flag.IntVar(&opts.Table, "table", 0, "For "+modesUsing(optTable)+", multiplication table to practice or step to count by, or zero for all")
+	flag.IntVar(&opts.Length, "length", 5, "For "+modesUsing(optLength)+", how many numbers to show in a sequence, 4 through 8")
+	flag.BoolVar(&opts.Fractional, "fractional", false, "For "+modesUsing(optFractional)+", allow x to be a fraction, like x = 2/3")
 	flag.BoolVar(&opts.Quick, "quick", false, "Quickly start")
 	flag.BoolVar(&opts.NoSounds, "no-sounds", false, "Prevent playing sounds")
+	flag.StringVar(&opts.Scheduler, "scheduler", "leitner", "How to pick the next problem: leitner, sm2 or random")
 	flag.Parse()
 
+	sched, err := NewScheduler(opts.Scheduler)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	m.sched = sched
+
+	if m.words, err = loadWordBank(opts.Words); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	if m.units, err = loadUnitTable(opts.Units); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
 	if !opts.NoSounds {
 		m.otoContext = NewOtoContext()
 	}
-	if opts.Mode <= 0 || opts.Mode > 4 || opts.Player == "" {
+	if opts.Mix != "" {
+		if m.mix, err = parseMix(opts.Mix); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		opts.Mode = int(modeMixed)
+	}
+	if _, ok := modeNames[mode(opts.Mode)]; !ok || opts.Player == "" || (mode(opts.Mode) == modeMixed && m.mix == nil) {
 		return m
 	}
+	if opts.Ops != "" {
+		if m.ops, err = parseOps(opts.Ops); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	}
 	m.mode = mode(opts.Mode)
 	m.player = opts.Player
+	m.simplify = opts.Simplify
+	m.depth = min(max(opts.Depth, 1), 3)
+	m.parens = opts.Parens
+	m.blanks = opts.Blanks
+	m.bonds = opts.Bonds
+	m.columns = opts.Columns
+	m.limit = min(max(opts.Limit, 1), 30)
+	m.length = min(max(opts.Length, 4), 8)
+	m.fractional = opts.Fractional
+	m.caret = opts.Caret
+	m.currency = opts.Currency
 	m.digits = opts.Digits
+	m.places = min(max(opts.Places, 1), 3)
 	m.table = opts.Table
 
 	if m.table < 0 {
@@ -523,11 +807,15 @@ func parseFlags(m model) model {
 	if m.table > mathTableEnd {
 		m.table = mathTableEnd
 	}
+	if m.table == 1 && m.uses(modeDivRem) {
+		fmt.Println("Error: divr needs a table of 2 or more, dividing by 1 never has a remainder")
+		os.Exit(1)
+	}
 	if m.digits < 1 {
-		m.table = 1
+		m.digits = 1
 	}
-	if m.digits > 3 {
-		m.digits = 3
+	if m.digits > maxDigits(m.mode) {
+		m.digits = maxDigits(m.mode)
 	}
 	if opts.Quick {
 		m.splashWait = 0
@@ -552,57 +840,203 @@ func runNewGameForm(m model) model {
 		Key("mode").
 		Title("What would you like to practice?").
 		Value(&m.mode).
+		Options(modeOptions(true)...)
+
+	// For a mix, select what to mix together
+	var mixed []mode
+	mixI := huh.NewMultiSelect[mode]().
+		Key("mix").
+		Title("What should we mix together?").
+		Value(&mixed).
+		Options(modeOptions(false)...).
+		Validate(func(mds []mode) error {
+			if len(mds) < 2 {
+				return errors.New("please pick at least two")
+			}
+			return nil
+		})
+
+	// Which of the modes are being played, so we only ask for the options that matter
+	played := func() []mode {
+		if m.mode != modeMixed {
+			return []mode{m.mode}
+		}
+		return mixed
+	}
+	uses := func(o option) bool {
+		return slices.ContainsFunc(played(), func(md mode) bool { return md.uses(o) })
+	}
+
+	// Which multiplication table to use for mul/div/divr, or to count by for seq
+	var table string
+	tableI := huh.NewInput().Key("table").Value(&table).TitleFunc(func() string {
+		if m.mode == modeSequence {
+			return fmt.Sprintf("Count by what? (1-%d or all)", mathTableEnd)
+		}
+		return fmt.Sprintf("Which table? (1-%d or all)", mathTableEnd)
+	}, &m.mode).Validate(func(s string) error {
+		if s == "all" {
+			return nil
+		}
+		num, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.New("please enter a number")
+		}
+		if num < 1 || num > mathTableEnd {
+			return fmt.Errorf("please enter 1 through %d or all", mathTableEnd)
+		}
+		if num == 1 && slices.Contains(played(), modeDivRem) {
+			return fmt.Errorf("please enter 2 through %d or all, dividing by 1 never has a remainder", mathTableEnd)
+		}
+		return nil
+	})
+
+	// Number of whole number digits, and decimal places for dec
+	oneToMax := func(highest int) func(string) error {
+		return func(s string) error {
+			num, err := strconv.Atoi(s)
+			if err != nil {
+				return errors.New("please enter a number")
+			}
+			if num < 1 || num > highest {
+				return fmt.Errorf("please enter 1 through %d", highest)
+			}
+			return nil
+		}
+	}
+	var digits, places string
+	digitsI := huh.NewInput().Key("digits").Value(&digits).TitleFunc(func() string {
+		if m.mode == modeDecimal {
+			return "How many whole number digits max?"
+		}
+		return "How many digits max?"
+	}, &m.mode).Validate(func(s string) error { return oneToMax(maxDigits(m.mode))(s) })
+	placesI := huh.NewInput().Key("places").Value(&places).Title("How many decimal places?").Validate(oneToMax(3))
+
+	// Which operations to practice for modes like fractions, decimals and word problems
+	opsI := huh.NewMultiSelect[mode]().
+		Key("ops").
+		Title("Which operations?").
+		Value(&m.ops).
 		Options(
 			huh.NewOption("Addition", modeAdd),
 			huh.NewOption("Subtraction", modeSub),
 			huh.NewOption("Multiplication", modeMul),
 			huh.NewOption("Division", modeDiv),
+		).
+		Validate(func(ops []mode) error {
+			if len(ops) == 0 {
+				return errors.New("please pick at least one")
+			}
+			return nil
+		})
+
+	// Fractions can require the answer to be simplified
+	simplifyI := huh.NewConfirm().Key("simplify").Value(&m.simplify).Title("Do answers have to be simplified?")
+
+	// How hard to make order of operations expressions
+	depthI := huh.NewSelect[int]().
+		Key("depth").
+		Title("How many steps?").
+		Value(&m.depth).
+		Options(
+			huh.NewOption("One step, like 3 + 4", 1),
+			huh.NewOption("Up to three steps, like 3 + 4 x 2", 2),
+			huh.NewOption("Up to seven steps, like 3 + 4 x 2 - 6 / 3", 3),
 		)
+	parensI := huh.NewConfirm().Key("parens").Value(&m.parens).Title("Use parentheses?")
 
-	// Based on mode, either select number of digits (sub/add) or which multiplication table to use
-	var modeOpt string
-	modeOptI := huh.NewInput().Key("modeOpt").Value(&modeOpt).TitleFunc(func() string {
-		if m.mode == modeMul || m.mode == modeDiv {
-			return fmt.Sprintf("Which table? (1-%d or all)", mathTableEnd)
+	// Basic operations can hide a number instead of the answer
+	blanksI := huh.NewConfirm().Key("blanks").Value(&m.blanks).Title("Fill in the blanks, like 7 x ? = 56?")
+
+	// Addition can practice number bonds instead of every sum
+	bondsI := huh.NewConfirm().Key("bonds").Value(&m.bonds).Title("Practice number bonds and making ten, like 7 + ? = 10?")
+
+	// Add and subtract can be stacked in columns, like on paper
+	columnsI := huh.NewConfirm().Key("columns").Value(&m.columns).Title("Stack numbers in columns, like on paper?")
+
+	// Biggest number for powers, and how to show them
+	var limit string
+	limitI := huh.NewInput().Key("limit").Value(&limit).Title("Square numbers up to what? (1-30)").Validate(func(s string) error {
+		num, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.New("please enter a number")
 		}
-		return "How many digits max?"
-	}, &m.mode).Validate(func(s string) error {
-		if (m.mode == modeMul || m.mode == modeDiv) && s == "all" {
-			return nil
+		if num < 1 || num > 30 {
+			return errors.New("please enter 1 through 30")
 		}
+		return nil
+	})
+	caretI := huh.NewConfirm().Key("caret").Value(&m.caret).Title("Show powers like 7^2 instead of 7²?")
+
+	// Equations can have answers that are fractions
+	fractionalI := huh.NewConfirm().Key("fractional").Value(&m.fractional).Title("Allow answers that are fractions, like x = 2/3?")
+
+	// How many numbers to show in a sequence
+	var length string
+	lengthI := huh.NewInput().Key("length").Value(&length).Title("How many numbers in each pattern? (4-8)").Validate(func(s string) error {
 		num, err := strconv.Atoi(s)
 		if err != nil {
 			return errors.New("please enter a number")
 		}
-		if m.mode == modeMul || m.mode == modeDiv {
-			if num < 1 || num > mathTableEnd {
-				return fmt.Errorf("please enter 1 through %d or all", mathTableEnd)
-			}
-		} else if num < 1 || num > 3 {
-			return errors.New("please enter 1 through 3")
+		if num < 4 || num > 8 {
+			return errors.New("please enter 4 through 8")
+		}
+		return nil
+	})
+
+	// Currency symbol for money
+	currencyI := huh.NewInput().Key("currency").Value(&m.currency).Title("Which currency symbol?").Validate(func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return errors.New("please enter a currency symbol, like $")
 		}
 		return nil
 	})
 
 	// Display form, full screen
-	form := huh.NewForm(huh.NewGroup(title, playerI, modeI, modeOptI)).WithProgramOptions(tea.WithAltScreen())
+	form := huh.NewForm(
+		huh.NewGroup(title, playerI, modeI),
+		huh.NewGroup(mixI).WithHideFunc(func() bool { return m.mode != modeMixed }),
+		huh.NewGroup(tableI).WithHideFunc(func() bool { return !uses(optTable) }),
+		huh.NewGroup(digitsI).WithHideFunc(func() bool { return !uses(optDigits) }),
+		huh.NewGroup(placesI).WithHideFunc(func() bool { return !uses(optPlaces) }),
+		huh.NewGroup(blanksI).WithHideFunc(func() bool { return !uses(optBlanks) }),
+		huh.NewGroup(bondsI).WithHideFunc(func() bool { return !uses(optBonds) }),
+		huh.NewGroup(columnsI).WithHideFunc(func() bool { return !uses(optColumns) || m.blanks }),
+		huh.NewGroup(opsI).WithHideFunc(func() bool { return !uses(optOps) }),
+		huh.NewGroup(simplifyI).WithHideFunc(func() bool { return !uses(optSimplify) }),
+		huh.NewGroup(depthI, parensI).WithHideFunc(func() bool { return !uses(optDepth) }),
+		huh.NewGroup(limitI, caretI).WithHideFunc(func() bool { return !uses(optLimit) }),
+		huh.NewGroup(lengthI).WithHideFunc(func() bool { return !uses(optLength) }),
+		huh.NewGroup(fractionalI).WithHideFunc(func() bool { return !uses(optFractional) }),
+		huh.NewGroup(currencyI).WithHideFunc(func() bool { return !uses(optCurrency) }),
+	).WithProgramOptions(tea.WithAltScreen())
 	if err := form.Run(); err != nil {
 		fmt.Println("Error:", err)
 		os.Exit(1)
 	}
-	if (m.mode == modeMul || m.mode == modeDiv) && modeOpt == "all" {
-		modeOpt = "0"
+
+	// Save options to model, they were validated, so ignore errors
+	if m.mode == modeMixed {
+		m.mix = make(map[mode]int)
+		for _, md := range mixed {
+			m.mix[md] = 1
+		}
 	}
-	num, err := strconv.Atoi(modeOpt)
-	if err != nil { // Shouldn't happen, but have to handle it
-		fmt.Println("Error: must enter a number - ", err)
-		os.Exit(1)
+	if uses(optTable) && table != "all" {
+		m.table, _ = strconv.Atoi(table)
 	}
-	// Save modeOpt to model
-	if m.mode == modeMul || m.mode == modeDiv {
-		m.table = num
-	} else {
-		m.digits = num
+	if uses(optDigits) {
+		m.digits, _ = strconv.Atoi(digits)
+	}
+	if uses(optPlaces) {
+		m.places, _ = strconv.Atoi(places)
+	}
+	if uses(optLimit) {
+		m.limit, _ = strconv.Atoi(limit)
+	}
+	if uses(optLength) {
+		m.length, _ = strconv.Atoi(length)
 	}
 	return m
 }
@@ -616,6 +1050,23 @@ func rainbow(base lipgloss.Style, s string, colors []color.Color) string {
 	return str
 }
 
+// rainbowLines is rainbow for text with more than one line
+func rainbowLines(base lipgloss.Style, s string, colors []color.Color) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = rainbow(base, line, colors)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrap long text, like word problems, to fit the window
+func wrap(s string, width int) string {
+	if width <= 0 {
+		return s // Window size isn't known yet
+	}
+	return ansi.Wrap(s, width, "")
+}
+
 func funMessage(message string, windowWidth int) string {
 	dialogBoxStyle := style.
 		Border(lipgloss.RoundedBorder()).
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/huh"
+)
+
+// modeNames are used for flags, like -mix add:2,mul:1
+var modeNames = map[mode]string{
+	modeAdd:      "add",
+	modeSub:      "sub",
+	modeMul:      "mul",
+	modeDiv:      "div",
+	modeMixed:    "mixed",
+	modeDivRem:   "divr",
+	modeFrac:     "frac",
+	modeDecimal:  "dec",
+	modeInt:      "int",
+	modeExpr:     "expr",
+	modeWords:    "words",
+	modePowers:   "pow",
+	modeFactors:  "factors",
+	modePlace:    "place",
+	modeMoney:    "money",
+	modeTime:     "time",
+	modeUnits:    "units",
+	modeCompare:  "compare",
+	modeSequence: "seq",
+	modeAlgebra:  "algebra",
+	modePercent:  "percent",
+}
+
+// option is a setting in the new game form that only some modes use
+type option int
+
+const (
+	optTable option = iota
+	optDigits
+	optOps
+	optSimplify
+	optPlaces
+	optDepth // And parentheses
+	optBlanks
+	optLimit // And the caret
+	optCurrency
+	optLength
+	optFractional
+	optBonds
+	optColumns
+)
+
+// modeInfo is how a mode is shown in the new game form, and which options it uses
+type modeInfo struct {
+	mode    mode
+	title   string
+	options []option
+}
+
+// modeInfos are in the order they're shown in the new game form
+var modeInfos = []modeInfo{
+	{modeAdd, "Addition", []option{optDigits, optBlanks, optBonds, optColumns}},
+	{modeSub, "Subtraction", []option{optDigits, optBlanks, optColumns}},
+	{modeMul, "Multiplication", []option{optTable, optBlanks}},
+	{modeDiv, "Division", []option{optTable, optBlanks}},
+	{modeDivRem, "Division with remainders", []option{optTable}},
+	{modeFrac, "Fractions", []option{optOps, optSimplify}},
+	{modeDecimal, "Decimals", []option{optDigits, optPlaces, optOps}},
+	{modeInt, "Negative numbers", []option{optDigits, optOps}},
+	{modeExpr, "Order of operations", []option{optOps, optDepth}},
+	{modeWords, "Word problems", []option{optOps}},
+	{modePowers, "Powers and square roots", []option{optLimit}},
+	{modeFactors, "Factors and primes", []option{optDigits}},
+	{modePlace, "Place value and rounding", []option{optDigits}},
+	{modeMoney, "Money and making change", []option{optCurrency}},
+	{modeTime, "Telling time", nil},
+	{modeUnits, "Measurement and units", nil},
+	{modeCompare, "Comparing and ordering", []option{optDigits}},
+	{modeSequence, "Skip counting and patterns", []option{optTable, optLength}},
+	{modeAlgebra, "Solving for x", []option{optFractional}},
+	{modePercent, "Percentages and ratios", nil},
+	{modeMixed, "A mix", nil},
+}
+
+// uses returns true when the mode has the option
+func (md mode) uses(o option) bool {
+	i := slices.IndexFunc(modeInfos, func(info modeInfo) bool { return info.mode == md })
+	return i >= 0 && slices.Contains(modeInfos[i].options, o)
+}
+
+// modesUsing lists the modes with the option for flag help, like "add/sub"
+func modesUsing(o option) string {
+	var names []string
+	for _, info := range modeInfos {
+		if info.mode.uses(o) {
+			names = append(names, info.mode.String())
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// modeOptions are the choices for picking a mode, with "A mix" only when mix is true
+func modeOptions(mix bool) []huh.Option[mode] {
+	var opts []huh.Option[mode]
+	for _, info := range modeInfos {
+		if info.mode != modeMixed || mix {
+			opts = append(opts, huh.NewOption(info.title, info.mode))
+		}
+	}
+	return opts
+}
+
+// basicOps are the operations that modes like fractions can be practiced with
+var basicOps = []mode{modeAdd, modeSub, modeMul, modeDiv}
+
+func (md mode) String() string {
+	if name, ok := modeNames[md]; ok {
+		return name
+	}
+	return fmt.Sprintf("mode(%d)", int(md))
+}
+
+// modeHelp lists each mode and its number for the -mode flag
+func modeHelp() string {
+	var names []string
+	for md := modeAdd; ; md++ {
+		name, ok := modeNames[md]
+		if !ok {
+			break
+		}
+		names = append(names, fmt.Sprintf("%s=%d", name, md))
+	}
+	return "Game mode, " + strings.Join(names, ", ")
+}
+
+// parseMix reads the modes to mix and their weights, like "add:2,mul:1" or just "add,mul"
+func parseMix(s string) (map[mode]int, error) {
+	mix := make(map[mode]int)
+	for part := range strings.SplitSeq(s, ",") {
+		name, weight, found := strings.Cut(strings.TrimSpace(part), ":")
+		md := modeNone
+		for m, n := range modeNames {
+			if n == name && m != modeMixed {
+				md = m
+			}
+		}
+		if md == modeNone {
+			return nil, fmt.Errorf("unknown mode %q in mix", name)
+		}
+		w := 1
+		if found {
+			var err error
+			if w, err = strconv.Atoi(weight); err != nil || w < 1 {
+				return nil, fmt.Errorf("weight for %s must be a number above zero", name)
+			}
+		}
+		mix[md] = w
+	}
+	return mix, nil
+}
+
+// parseOps reads which operations to practice, like "add,sub"
+func parseOps(s string) ([]mode, error) {
+	var ops []mode
+	for name := range strings.SplitSeq(s, ",") {
+		name = strings.TrimSpace(name)
+		i := slices.IndexFunc(basicOps, func(op mode) bool { return op.String() == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown operation %q, use add, sub, mul or div", name)
+		}
+		if !slices.Contains(ops, basicOps[i]) {
+			ops = append(ops, basicOps[i])
+		}
+	}
+	return ops, nil
+}
+
+// operations to practice for modes like fractions, defaults to all of them
+func (m model) operations() []mode {
+	if len(m.ops) == 0 {
+		return basicOps
+	}
+	return m.ops
+}
+
+// modes that are being played, which is more than one for a mix
+func (m model) modes() []mode {
+	if m.mode != modeMixed {
+		return []mode{m.mode}
+	}
+	var mds []mode
+	for md := range m.mix {
+		mds = append(mds, md)
+	}
+	slices.Sort(mds)
+	return mds
+}
+
+// uses returns true when any of the modes are being played
+func (m model) uses(mds ...mode) bool {
+	for _, md := range m.modes() {
+		if slices.Contains(mds, md) {
+			return true
+		}
+	}
+	return false
+}
+
+// newProblems generates every problem for the modes being played
+func (m model) newProblems() problems {
+	var all problems
+	for _, md := range m.modes() {
+		var p problems
+		switch md {
+		case modeMul:
+			p = NewMulProblems(m.table)
+		case modeDiv:
+			p = NewDivProblems(m.table)
+		case modeDivRem:
+			p = NewDivRemProblems(m.table)
+		case modeAdd:
+			p = NewAddProblems(m.digits)
+			if m.bonds {
+				p = NewBondProblems()
+			}
+		case modeSub:
+			p = NewSubProblems(m.digits)
+		case modeFrac:
+			p = NewFracProblems(m.operations(), m.simplify)
+		case modeDecimal:
+			p = NewDecimalProblems(m.operations(), m.digits, m.places)
+		case modeInt:
+			p = NewIntegerProblems(m.operations(), m.digits)
+		case modeExpr:
+			p = NewExprProblems(m.operations(), m.depth, m.parens)
+		case modeWords:
+			p = NewWordProblems(m.words, m.operations())
+		case modePowers:
+			p = NewPowerProblems(m.limit, m.caret)
+		case modeFactors:
+			p = NewFactorProblems(m.digits)
+		case modePlace:
+			p = NewPlaceValueProblems(m.digits)
+		case modeMoney:
+			p = NewMoneyProblems(strings.TrimSpace(m.currency))
+		case modeTime:
+			p = NewTimeProblems()
+		case modeUnits:
+			p = NewUnitProblems(m.units)
+		case modeCompare:
+			p = NewCompareProblems(m.digits)
+		case modeSequence:
+			p = NewSequenceProblems(m.table, m.length)
+		case modeAlgebra:
+			p = NewAlgebraProblems(m.fractional)
+		case modePercent:
+			p = NewPercentProblems()
+		default:
+			panic("forgot to implment problems for new game mode")
+		}
+		if m.blanks && !(md == modeAdd && m.bonds) { // Bonds already have a blank
+			p = NewBlankProblems(p, md)
+		}
+		for i := range p {
+			p[i].kind = md
+		}
+		all = append(all, p...)
+	}
+	return all
+}
+
+// mixedScheduler first picks the kind of problem by weight, then lets
+// the wrapped scheduler pick a problem of that kind
+type mixedScheduler struct {
+	scheduler
+	weights map[mode]int
+}
+
+func (s mixedScheduler) Next(p problems, last problem, now time.Time) problem {
+	byKind := make(map[mode]problems)
+	for _, prob := range p {
+		byKind[prob.kind] = append(byKind[prob.kind], prob)
+	}
+	kinds := make([]mode, 0, len(byKind))
+	total := 0
+	for kind := range byKind {
+		kinds = append(kinds, kind)
+		total += max(s.weights[kind], 1)
+	}
+	slices.Sort(kinds) // Map order is random, so sort to make the weights fair
+	pick := rand.Intn(total)
+	for _, kind := range kinds {
+		if pick -= max(s.weights[kind], 1); pick < 0 {
+			return s.scheduler.Next(byKind[kind], last, now)
+		}
+	}
+	panic("mixed scheduler failed to pick a kind")
+}
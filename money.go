@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// coins are the values of the coins to count, in cents
+var coins = []int{25, 10, 5, 1}
+
+// moneyAnswer is an amount of money in cents
+type moneyAnswer struct {
+	cents  int
+	symbol string // Currency symbol, like $
+}
+
+func (a moneyAnswer) format(cents int) string {
+	return fmt.Sprintf("%s%d.%02d", a.symbol, cents/100, cents%100)
+}
+
+// Parse reads "$1.35", "1.35", "135c" or "135¢"
+func (a moneyAnswer) Parse(s string) (answer, error) {
+	errFormat := fmt.Errorf("please enter money like %s or 135c", a.format(135))
+	s = strings.TrimSpace(s)
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(s, a.symbol), "$"))
+	if c, ok := strings.CutSuffix(s, "c"); ok {
+		s = strings.TrimSpace(c) + "¢"
+	}
+	if c, ok := strings.CutSuffix(s, "¢"); ok {
+		cents, err := strconv.Atoi(strings.TrimSpace(c))
+		if err != nil {
+			return nil, errFormat
+		}
+		return moneyAnswer{cents: cents, symbol: a.symbol}, nil
+	}
+	d, err := parseDecimal(s)
+	if err != nil || d.places > 2 {
+		return nil, errFormat
+	}
+	return moneyAnswer{cents: d.units * pow10(2-d.places), symbol: a.symbol}, nil
+}
+
+func (a moneyAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(moneyAnswer)
+	switch {
+	case !ok:
+		return false, ""
+	case g.cents == a.cents:
+		return true, ""
+	case g.cents == a.cents*100:
+		return false, fmt.Sprintf("Did you mean %dc? Add a c for cents.", a.cents)
+	}
+	return false, ""
+}
+
+func (a moneyAnswer) String() string {
+	return a.format(a.cents)
+}
+
+// drawCoins renders coins as ASCII art, side by side
+func drawCoins(values []int) string {
+	var top, mid, bottom []string
+	for _, v := range values {
+		top = append(top, "╭───╮")
+		mid = append(mid, fmt.Sprintf("│%2d¢│", v))
+		bottom = append(bottom, "╰───╯")
+	}
+	return strings.Join([]string{
+		strings.Join(top, " "),
+		strings.Join(mid, " "),
+		strings.Join(bottom, " "),
+	}, "\n")
+}
+
+// NewMoneyProblems makes change, adds prices and counts coins
+func NewMoneyProblems(symbol string) problems {
+	m := moneyAnswer{symbol: symbol}
+	money := func(cents int) moneyAnswer {
+		return moneyAnswer{cents: cents, symbol: symbol}
+	}
+
+	change := NewSampledProblems(sampleSize/3, func() problem {
+		paid := []int{100, 500, 1000, 2000}[rand.Intn(4)]
+		price := 5 * (1 + rand.Intn(paid/5-1)) // Prices to the nickel, less than paid
+		q := fmt.Sprintf("How much change from %s for %s?", m.format(paid), m.format(price))
+		prob := NewProblem(q, money(paid-price))
+		prob.prompt = q
+		prob.solution = fmt.Sprintf("%s - %s = %s", m.format(paid), m.format(price), m.format(paid-price))
+		return prob
+	})
+
+	prices := NewSampledProblems(sampleSize/3, func() problem {
+		a, b := 5*(1+rand.Intn(199)), 5*(1+rand.Intn(199))
+		q := fmt.Sprintf("%s + %s", m.format(a), m.format(b))
+		return NewProblem(q, money(a+b))
+	})
+
+	counting := NewSampledProblems(sampleSize/3, func() problem {
+		values := make([]int, 2+rand.Intn(6))
+		total := 0
+		for i := range values {
+			values[i] = coins[rand.Intn(len(coins))]
+			total += values[i]
+		}
+		slices.Sort(values)
+		slices.Reverse(values)
+		var parts []string
+		for _, v := range values {
+			parts = append(parts, fmt.Sprintf("%d¢", v))
+		}
+		prob := NewProblem("Count "+strings.Join(parts, " "), money(total))
+		prob.prompt = "How much money is this?\n\n" + drawCoins(values)
+		prob.solution = fmt.Sprintf("%s = %s", strings.Join(parts, " + "), m.format(total))
+		return prob
+	})
+
+	return append(append(change, prices...), counting...)
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestMoneyAnswer(t *testing.T) {
+	price := moneyAnswer{cents: 135, symbol: "$"}
+	checkAnswers(t, []answerTest{
+		{"dollars", price, "$1.35", true, "", false},
+		{"no symbol", price, "1.35", true, "", false},
+		{"cents", price, "135c", true, "", false},
+		{"cent sign", price, "135 ¢", true, "", false},
+		{"other symbol", moneyAnswer{cents: 135, symbol: "€"}, "€1.35", true, "", false},
+		{"whole dollars", moneyAnswer{cents: 200, symbol: "$"}, "2", true, "", false},
+		{"one place", moneyAnswer{cents: 150, symbol: "$"}, "1.5", true, "", false},
+		{"wrong", price, "1.53", false, "", false},
+		{"cents as dollars", moneyAnswer{cents: 35, symbol: "$"}, "35", false, "Did you mean 35c? Add a c for cents.", false},
+		{"too many places", price, "1.355", false, "", true},
+		{"not money", price, "lots", false, "", true},
+	})
+	checkRoundTrip(t, price, moneyAnswer{cents: 1205, symbol: "$"}, moneyAnswer{cents: 5, symbol: "€"})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// numberAnswer is a number that can have a decimal, like 4.5 or 33.3%. When it doesn't
+// fit in two decimal places, any answer rounded to at least one place is right.
+type numberAnswer struct {
+	value   fraction
+	places  int  // Decimal places the player wrote
+	percent bool // Show the answer with a %
+}
+
+// Parse reads "4.5", "4.50", "25%" or "1/3"
+func (a numberAnswer) Parse(s string) (answer, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	if strings.Contains(s, "/") {
+		f, _, err := parseFraction(s)
+		if err != nil {
+			return nil, errors.New("please enter a number like 4.5")
+		}
+		return numberAnswer{value: f}, nil
+	}
+	d, err := parseDecimal(strings.ReplaceAll(s, ",", ""))
+	if err != nil {
+		return nil, errors.New("please enter a number like 4.5")
+	}
+	return numberAnswer{value: newFraction(d.units, pow10(d.places)), places: d.places}, nil
+}
+
+func (a numberAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(numberAnswer)
+	switch {
+	case !ok:
+		return false, ""
+	case g.value == a.value:
+		return true, ""
+	case a.value.mul(newFraction(100, 1)).den == 1:
+		return false, ""
+	}
+	// Close enough when it's rounded to the places the player wrote, like 33.3 for 33 1/3
+	diff := g.value.sub(a.value)
+	if diff.num < 0 {
+		diff.num = -diff.num
+	}
+	switch {
+	case g.places > 0 && diff.less(newFraction(1, 2*pow10(g.places))):
+		return true, ""
+	case diff.less(newFraction(1, 1)):
+		return false, "Close, check your rounding to one decimal place."
+	}
+	return false, ""
+}
+
+// String rounds to two decimal places
+func (a numberAnswer) String() string {
+	hundredths := (a.value.num*200/a.value.den + 1) / 2
+	s := decimal{units: hundredths, places: 2}.normal().String()
+	if a.value.mul(newFraction(100, 1)).den != 1 {
+		s = "about " + s
+	}
+	if a.percent {
+		s += "%"
+	}
+	return s
+}
+
+// percents are the ones kids see most, easy ones first so they come up more
+var percents = []int{10, 50, 25, 20, 75, 5, 1, 100, 30, 40, 60, 80, 90, 15, 200}
+
+// NewPercentProblems makes percent of a number, what percent one number is of another and
+// proportion problems
+func NewPercentProblems() problems {
+	of := NewSampledProblems(sampleSize/3, func() problem {
+		p, n := percents[rand.Intn(len(percents))], 2+rand.Intn(199)
+		q := fmt.Sprintf("What is %d%% of %d?", p, n)
+		ans := numberAnswer{value: newFraction(p*n, 100)}
+		prob := NewProblem(q, ans)
+		prob.prompt = q
+		prob.solution = fmt.Sprintf("%d%% of %d = %d/100 x %d = %s", p, n, p, n, ans)
+		return prob
+	})
+
+	whatPercent := NewSampledProblems(sampleSize/3, func() problem {
+		b := 2 + rand.Intn(99)
+		a := 1 + rand.Intn(b)
+		if rand.Intn(4) > 0 {
+			// Mostly use percents that come out even
+			even := slices.DeleteFunc(slices.Clone(percents), func(p int) bool { return p*b%100 != 0 })
+			if len(even) > 0 {
+				a = even[rand.Intn(len(even))] * b / 100
+			}
+		}
+		q := fmt.Sprintf("%d is what percent of %d?", a, b)
+		ans := numberAnswer{value: newFraction(a*100, b), percent: true}
+		prob := NewProblem(q, ans)
+		prob.prompt = q
+		prob.solution = fmt.Sprintf("%d / %d x 100 = %s", a, b, ans)
+		return prob
+	})
+
+	proportions := NewSampledProblems(sampleSize/3, func() problem {
+		a, b := 1+rand.Intn(9), 1+rand.Intn(9)
+		for gcd(a, b) != 1 || a == b {
+			a, b = 1+rand.Intn(9), 1+rand.Intn(9)
+		}
+		n := 2 + rand.Intn(9)
+		q := fmt.Sprintf("%d:%d = %d:?", a, b, a*n)
+		ans := b * n
+		if rand.Intn(2) == 0 {
+			q, ans = fmt.Sprintf("%d:%d = ?:%d", a, b, b*n), a*n
+		}
+		prob := NewProblem(q, numberAnswer{value: newFraction(ans, 1)})
+		prob.prompt = "Fill in the missing number: " + q
+		prob.solution = fmt.Sprintf("%d:%d = %d:%d, both sides times %d", a, b, a*n, b*n, n)
+		return prob
+	})
+
+	return slices.Concat(of, whatPercent, proportions)
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestNumberAnswer(t *testing.T) {
+	half := numberAnswer{value: newFraction(9, 2)}
+	third := numberAnswer{value: newFraction(100, 3), percent: true}
+	checkAnswers(t, []answerTest{
+		{"decimal", half, "4.5", true, "", false},
+		{"trailing zero", half, "4.50", true, "", false},
+		{"fraction", half, "9/2", true, "", false},
+		{"percent", numberAnswer{value: newFraction(25, 1), percent: true}, "25%", true, "", false},
+		{"percent without sign", numberAnswer{value: newFraction(25, 1), percent: true}, "25", true, "", false},
+		{"exact is wrong", half, "4.6", false, "", false},
+		{"rounded", third, "33.3", true, "", false},
+		{"rounded more", third, "33.33%", true, "", false},
+		{"rounded wrong", third, "33.4", false, "Close, check your rounding to one decimal place.", false},
+		{"not rounded", third, "33", false, "Close, check your rounding to one decimal place.", false},
+		{"far off", third, "40", false, "", false},
+		{"not a number", half, "four", false, "", true},
+	})
+	checkRoundTrip(t, half, third, numberAnswer{value: newFraction(25, 1), percent: true}, numberAnswer{value: newFraction(1, 8)})
+}
+
+func TestNumberAnswerString(t *testing.T) {
+	tests := []struct {
+		ans  numberAnswer
+		want string
+	}{
+		{numberAnswer{value: newFraction(9, 2)}, "4.5"},
+		{numberAnswer{value: newFraction(25, 1), percent: true}, "25%"},
+		{numberAnswer{value: newFraction(100, 3), percent: true}, "about 33.33%"},
+		{numberAnswer{value: newFraction(200, 3)}, "about 66.67"},
+	}
+	for _, tt := range tests {
+		if got := tt.ans.String(); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// placeNames are the names of the places in a number, from the ones place up
+var placeNames = []string{"ones", "tens", "hundreds", "thousands", "ten thousands", "hundred thousands"}
+
+// maxDigits is how many digits the digits option can go up to for the mode
+func maxDigits(md mode) int {
+	if md == modePlace {
+		return len(placeNames)
+	}
+	return 3
+}
+
+// commas formats a number with thousands separators, like 3,478
+func commas(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// roundTo rounds n to the nearest place, like 100, with halves rounding up
+func roundTo(n, place int) int {
+	return (n + place/2) / place * place
+}
+
+// singular place name, so "tens" becomes "ten" for "the nearest ten"
+func singular(place string) string {
+	return place[:len(place)-1]
+}
+
+// NewPlaceValueProblems asks about rounding, digits in each place and estimating with
+// numbers up to digits digits
+func NewPlaceValueProblems(digits int) problems {
+	random := func(digits int) int {
+		low := pow10(digits - 1)
+		return low + rand.Intn(pow10(digits)-low)
+	}
+
+	// Rounding to a place smaller than the number, or to the nearest ten for one digit numbers
+	rounding := NewSampledProblems(sampleSize/3, func() problem {
+		n := random(digits)
+		place := 1 + rand.Intn(max(digits-1, 1))
+		q := fmt.Sprintf("Round %s to the nearest %s", commas(n), singular(placeNames[place]))
+		ans := roundTo(n, pow10(place))
+		prob := NewProblem(q, intAnswer(ans))
+		prob.prompt = q
+		prob.solution = fmt.Sprintf("%s rounds to %s", commas(n), commas(ans))
+		return prob
+	})
+
+	places := NewSampledProblems(sampleSize/3, func() problem {
+		n := random(max(digits, 2))
+		place := rand.Intn(len(strconv.Itoa(n)))
+		digit := n / pow10(place) % 10
+		q := fmt.Sprintf("What digit is in the %s place of %s?", placeNames[place], commas(n))
+		prob := NewProblem(q, intAnswer(digit))
+		prob.prompt = q
+		prob.solution = fmt.Sprintf("The %s place of %s is %d", placeNames[place], commas(n), digit)
+		return prob
+	})
+
+	// Estimate by rounding each number to its biggest place, up to three digits
+	// each, so the numbers stay small enough for mental math
+	estimates := NewSampledProblems(sampleSize/3, func() problem {
+		d := min(max(digits, 2), 3)
+		a, b := random(d), random(d)
+		ra, rb := roundTo(a, pow10(d-1)), roundTo(b, pow10(d-1))
+		var q, rounded string
+		var exact, estimate int
+		switch rand.Intn(3) {
+		case 0:
+			q, rounded, exact, estimate = fmt.Sprintf("%d + %d", a, b), fmt.Sprintf("%d + %d", ra, rb), a+b, ra+rb
+		case 1:
+			a, b, ra, rb = max(a, b), min(a, b), max(ra, rb), min(ra, rb)
+			q, rounded, exact, estimate = fmt.Sprintf("%d - %d", a, b), fmt.Sprintf("%d - %d", ra, rb), a-b, ra-rb
+		default:
+			b = random(2) // Keep the second number to two digits
+			rb = roundTo(b, 10)
+			q, rounded, exact, estimate = fmt.Sprintf("%d x %d", a, b), fmt.Sprintf("%d x %d", ra, rb), a*b, ra*rb
+		}
+		// Anything from the rounded estimate to the exact answer is fine, or at least within 10%
+		prob := NewProblem("Estimate "+q, approxAnswer{value: exact, tolerance: max(exact-estimate, estimate-exact, exact/10)})
+		prob.prompt = fmt.Sprintf("Estimate %s by rounding", q)
+		prob.solution = fmt.Sprintf("%s ≈ %s = %s (exactly %s)", q, rounded, commas(estimate), commas(exact))
+		return prob
+	})
+
+	return append(append(rounding, places...), estimates...)
+}
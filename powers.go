@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// powersMax is the biggest answer for small powers, like 2^10
+const powersMax = 1024
+
+var superscripts = strings.NewReplacer("0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴", "5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹")
+
+// power formats base to the exp, like 7² or 7^2 when caret is true
+func power(base, exp int, caret bool) string {
+	if caret {
+		return fmt.Sprintf("%d^%d", base, exp)
+	}
+	return strconv.Itoa(base) + superscripts.Replace(strconv.Itoa(exp))
+}
+
+// NewPowerProblems makes squares and square roots up to limit, cubes up to the
+// limit or 10, and other small powers like 2^5
+func NewPowerProblems(limit int, caret bool) problems {
+	var p problems
+	for n := 1; n <= limit; n++ {
+		p = append(p, NewProblem(power(n, 2, caret), intAnswer(n*n)))
+	}
+	for n := 1; n <= min(limit, 10); n++ {
+		p = append(p, NewProblem(power(n, 3, caret), intAnswer(n*n*n)))
+	}
+	for base := 2; base <= 10; base++ {
+		for exp, v := 0, 1; v <= powersMax; exp, v = exp+1, v*base {
+			if exp == 2 || exp == 3 {
+				continue // Already a square or cube
+			}
+			p = append(p, NewProblem(power(base, exp, caret), intAnswer(v)))
+		}
+	}
+	for n := 1; n <= limit; n++ {
+		p = append(p, NewProblem(fmt.Sprintf("√%d", n*n), intAnswer(n)))
+	}
+	return p
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// profile is what we remember about a player between sessions
+type profile struct {
+	Sessions int                `json:"sessions"`
+	Right    int                `json:"right"`
+	Wrong    int                `json:"wrong"`
+	History  map[string]history `json:"history"` // Keyed by mode and question, see historyKey
+}
+
+// history is the per-question record kept in a profile
+type history struct {
+	Seen     int           `json:"seen"`
+	Correct  int           `json:"correct"`
+	Wrong    int           `json:"wrong"`
+	LastSeen time.Time     `json:"last_seen"`
+	Latency  time.Duration `json:"latency"`
+	Box      int           `json:"box,omitempty"`
+	Ease     float64       `json:"ease,omitempty"`
+	Reps     int           `json:"reps,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+	Due      time.Time     `json:"due,omitzero"`
+}
+
+type profileStore struct {
+	path     string
+	Profiles map[string]profile `json:"profiles"` // Keyed by player name, see profileKey
+}
+
+// profilePath is where profiles are saved, following the XDG base directory spec
+func profilePath() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "go-math-tui", "profiles.json"), nil
+}
+
+// loadProfiles reads the profile store, a missing file is just an empty store
+func loadProfiles(path string) (*profileStore, error) {
+	s := &profileStore{path: path, Profiles: make(map[string]profile)}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	if s.Profiles == nil {
+		s.Profiles = make(map[string]profile)
+	}
+	for _, p := range s.Profiles {
+		p.prune()
+	}
+	return s, nil
+}
+
+// historyKey is the mode and question, like "mul/7 x 8", as the same question can be in more than one mode
+func historyKey(p problem) string {
+	return p.kind.String() + "/" + p.question
+}
+
+// prune drops history that can't be asked again, like from before it was keyed by mode
+func (p profile) prune() {
+	for key := range p.History {
+		name, _, found := strings.Cut(key, "/")
+		if !found || !slices.Contains(slices.Collect(maps.Values(modeNames)), name) {
+			delete(p.History, key)
+		}
+	}
+}
+
+// profileKey so "Ava" and "ava " are the same player
+func profileKey(player string) string {
+	return strings.ToLower(strings.TrimSpace(player))
+}
+
+func (s *profileStore) Get(player string) profile {
+	p := s.Profiles[profileKey(player)]
+	if p.History == nil {
+		p.History = make(map[string]history)
+	}
+	return p
+}
+
+// Record saves the results of a finished session into the player's profile
+func (s *profileStore) Record(m model) {
+	p := s.Get(m.player)
+	p.Sessions++
+	p.Right += m.totalRight
+	p.Wrong += m.totalWrong
+	for _, prob := range m.probs {
+		if prob.seen == 0 || prob.sampled {
+			continue // Random problems would only pile up, as they won't match next time
+		}
+		p.History[historyKey(prob)] = history{
+			Seen:     prob.seen,
+			Correct:  prob.correct,
+			Wrong:    prob.wrong,
+			LastSeen: prob.lastSeen,
+			Latency:  prob.latency,
+			Box:      prob.box,
+			Ease:     prob.ease,
+			Reps:     prob.reps,
+			Interval: prob.interval,
+			Due:      prob.due,
+		}
+	}
+	s.Profiles[profileKey(m.player)] = p
+}
+
+// Save writes the store to a temp file first, so a crash can't leave a half written file
+func (s *profileStore) Save() error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Apply copies the player's history onto the problems, so they pick up where they left off
+func (p profile) Apply(probs problems) {
+	for i, prob := range probs {
+		h, ok := p.History[historyKey(prob)]
+		if !ok {
+			continue
+		}
+		probs[i].seen, probs[i].correct, probs[i].wrong = h.Seen, h.Correct, h.Wrong
+		probs[i].lastSeen, probs[i].latency = h.LastSeen, h.Latency
+		probs[i].box, probs[i].ease, probs[i].reps = h.Box, h.Ease, h.Reps
+		probs[i].interval, probs[i].due = h.Interval, h.Due
+	}
+}
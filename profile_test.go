@@ -0,0 +1,85 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// mulProblems are the 7 times table, with the kind newProblems gives them
+func mulProblems() problems {
+	p := NewMulProblems(7)
+	for i := range p {
+		p[i].kind = modeMul
+	}
+	return p
+}
+
+func TestProfileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go-math-tui", "profiles.json")
+	s, err := loadProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel()
+	m.player = "Ava"
+	m.probs = mulProblems()
+	m.probs[2].seen, m.probs[2].correct, m.probs[2].wrong = 3, 2, 1
+	m.probs = append(m.probs, NewSampledProblems(1, func() problem { return problem{question: "GCD of 4 and 6", kind: modeFactors, seen: 1} })...)
+	m.totalRight, m.totalWrong = 2, 1
+	s.Record(m)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = loadProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := s.Get(" ava")
+	if p.Sessions != 1 || p.Right != 2 || p.Wrong != 1 {
+		t.Errorf("got %d sessions, %d right and %d wrong, want 1, 2 and 1", p.Sessions, p.Right, p.Wrong)
+	}
+	if len(p.History) != 1 {
+		t.Errorf("got history for %d problems, want only the one that was seen and not random", len(p.History))
+	}
+	probs := mulProblems()
+	p.Apply(probs)
+	if got := probs[2]; got.seen != 3 || got.correct != 2 || got.wrong != 1 {
+		t.Errorf("Apply gave %d seen, %d correct and %d wrong, want 3, 2 and 1", got.seen, got.correct, got.wrong)
+	}
+	if got := probs[3]; got.seen != 0 {
+		t.Errorf("Apply gave %d seen for a problem never asked", got.seen)
+	}
+
+	// The same question in another mode is a different problem
+	div := NewDivProblems(7)
+	for i := range div {
+		div[i].kind, div[i].question = modeDiv, probs[i].question
+	}
+	p.Apply(div)
+	if div[2].seen != 0 {
+		t.Errorf("Apply gave %d seen to a div problem, want history kept by mode", div[2].seen)
+	}
+}
+
+func TestProfilePrune(t *testing.T) {
+	p := profile{History: map[string]history{
+		"mul/7 x 8":     {Seen: 1},
+		"7 x 8":         {Seen: 1},
+		"nothing/7 x 8": {Seen: 1},
+	}}
+	p.prune()
+	if _, ok := p.History["mul/7 x 8"]; !ok || len(p.History) != 1 {
+		t.Errorf("got history %v, want only mul/7 x 8 kept", p.History)
+	}
+}
+
+func TestLoadProfilesMissing(t *testing.T) {
+	s, err := loadProfiles(filepath.Join(t.TempDir(), "profiles.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := s.Get("Ava"); p.Sessions != 0 || p.History == nil {
+		t.Errorf("got %+v, want an empty profile", p)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	reportTop   = 5  // How many questions to list under most missed and slowest
+	reportWidth = 40 // Longer questions, like word problems, are cut short
+)
+
+// reportKeys are the only keys that scroll the report, any other key quits
+var reportKeys = viewport.KeyMap{
+	Up:       key.NewBinding(key.WithKeys("up")),
+	Down:     key.NewBinding(key.WithKeys("down")),
+	PageUp:   key.NewBinding(key.WithKeys("pgup")),
+	PageDown: key.NewBinding(key.WithKeys("pgdown")),
+}
+
+// endGame stops play and switches to the report screen
+func (m model) endGame() (model, tea.Cmd) {
+	m.screen = screenEnd
+	m.heatmap = false
+	m.input.Blur()
+	m.report = viewport.New(m.windowWidth-4, m.reportHeight())
+	m.report.KeyMap = reportKeys
+	m.report.SetContent(m.reportContent())
+	return m, m.stopwatch.Stop()
+}
+
+// reportHeight is the window height, less the thanks message, padding and help text
+func (m model) reportHeight() int {
+	return max(m.windowHeight-9-5, 3)
+}
+
+// reportContent is the end of session summary, one line per fact that was practiced
+func (m model) reportContent() string {
+	var b strings.Builder
+	header := func(s string) {
+		b.WriteString("\n" + rainbow(style.Bold(true), s, blends) + "\n")
+	}
+	line := func(format string, a ...any) {
+		b.WriteString(style.Render(fmt.Sprintf(format, a...)) + "\n")
+	}
+
+	total := m.totalRight + m.totalWrong
+	accuracy := 0
+	if total > 0 {
+		accuracy = m.totalRight * 100 / total
+	}
+	b.WriteString(playtime(m.stopwatch.Elapsed()) + "\n")
+	header(fmt.Sprintf("Reached level %d", m.level))
+	line("Answered %d questions, %d right and %d wrong (%d%% correct)", total, m.totalRight, m.totalWrong, accuracy)
+
+	// Every question that was asked, most missed first
+	var facts []string
+	for q := range m.rightMap {
+		facts = append(facts, q)
+	}
+	for q := range m.wrongMap {
+		if _, ok := m.rightMap[q]; !ok {
+			facts = append(facts, q)
+		}
+	}
+	if len(facts) == 0 {
+		return b.String()
+	}
+	slices.SortFunc(facts, func(a, b string) int {
+		return cmp.Or(m.wrongMap[b]-m.wrongMap[a], m.rightMap[b]-m.rightMap[a], cmp.Compare(a, b))
+	})
+	width := 0
+	for _, q := range facts {
+		width = max(width, min(ansi.StringWidth(q), reportWidth))
+	}
+	short := func(q string) string {
+		return ansi.Truncate(q, reportWidth, "…")
+	}
+
+	if m.wrongMap[facts[0]] > 0 {
+		header("Most missed")
+		for _, q := range facts[:min(reportTop, len(facts))] {
+			if m.wrongMap[q] == 0 {
+				break
+			}
+			line("  %s  %d wrong", pad(short(q), width), m.wrongMap[q])
+		}
+	}
+
+	slowest := slices.Clone(facts)
+	slices.SortStableFunc(slowest, func(a, b string) int {
+		return cmp.Compare(m.slowMap[b], m.slowMap[a])
+	})
+	header("Slowest")
+	for _, q := range slowest[:min(reportTop, len(slowest))] {
+		line("  %s  %s", pad(short(q), width), m.slowMap[q].Round(time.Second/10))
+	}
+
+	header("Everything practiced")
+	line("  %s  %5s  %5s  %7s", pad("", width), "right", "wrong", "slowest")
+	for _, q := range facts {
+		line("  %s  %5d  %5d  %7s", pad(short(q), width), m.rightMap[q], m.wrongMap[q], m.slowMap[q].Round(time.Second/10))
+	}
+	return b.String()
+}
+
+// pad s with spaces to width, counting cells rather than bytes, so "÷" and "…" line up
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-ansi.StringWidth(s), 0))
+}
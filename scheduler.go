@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// fluentLatency is how quick an answer has to be before we consider the fact known, not just worked out
+const fluentLatency = 6 * time.Second
+
+// scheduler decides which problem to ask next
+type scheduler interface {
+	// Next picks the next problem to ask, avoiding last (the one just asked) when possible
+	Next(p problems, last problem, now time.Time) problem
+	// Review updates the scheduling state of a problem after the player answered it
+	Review(p problem, correct bool, latency time.Duration, now time.Time) problem
+}
+
+func NewScheduler(name string) (scheduler, error) {
+	switch name {
+	case "leitner":
+		return leitnerScheduler{boxes: 5}, nil
+	case "sm2":
+		return sm2Scheduler{}, nil
+	case "random":
+		return randomScheduler{}, nil
+	}
+	return nil, fmt.Errorf("unknown scheduler %q, use leitner, sm2 or random", name)
+}
+
+// randomScheduler is the original behavior, see problems.Random
+type randomScheduler struct{}
+
+func (randomScheduler) Next(p problems, _ problem, _ time.Time) problem {
+	return p.Random()
+}
+
+func (randomScheduler) Review(p problem, _ bool, _ time.Duration, _ time.Time) problem {
+	return p
+}
+
+// leitnerGap is how long a missed problem waits before it comes back, so it isn't asked
+// again right away, but doesn't wait behind every new problem either
+const leitnerGap = 15 * time.Second
+
+// leitnerScheduler puts every problem in a box. Quick correct answers move a problem up a box,
+// wrong answers send it back to the first box, and lower boxes are asked more often.
+type leitnerScheduler struct {
+	boxes int
+}
+
+func (s leitnerScheduler) box(p problem) int {
+	return min(max(p.box, 1), s.boxes) // Zero is a new problem, so it starts in the first box
+}
+
+func (s leitnerScheduler) Next(p problems, last problem, now time.Time) problem {
+	// Each box is asked half as often as the box below it. Boxes hold indexes into p,
+	// as copying the problems is slow when there are a lot of them.
+	byBox := make([][]int, s.boxes+1)
+	for i := range p {
+		if len(p) > 1 && p[i].question == last.question {
+			continue
+		}
+		b := s.box(p[i])
+		byBox[b] = append(byBox[b], i)
+	}
+	total := 0
+	for b := 1; b <= s.boxes; b++ {
+		if len(byBox[b]) > 0 {
+			total += 1 << (s.boxes - b)
+		}
+	}
+	pick := rand.Intn(total)
+	for b := 1; b <= s.boxes; b++ {
+		if len(byBox[b]) == 0 {
+			continue
+		}
+		if pick -= 1 << (s.boxes - b); pick < 0 {
+			if b == 1 {
+				return p[s.firstBox(p, byBox[b], now)]
+			}
+			return p[p.oldest(byBox[b])]
+		}
+	}
+	panic("leitner scheduler failed to pick a box")
+}
+
+// firstBox picks from the first box, where new problems start and missed ones go back to.
+// Missed problems come first once they've waited leitnerGap, then new problems, then
+// whichever missed problem has waited longest.
+func (s leitnerScheduler) firstBox(p problems, idx []int, now time.Time) int {
+	var missed, fresh []int
+	for _, i := range idx {
+		if p[i].lastSeen.IsZero() {
+			fresh = append(fresh, i)
+		} else {
+			missed = append(missed, i)
+		}
+	}
+	if i := p.oldest(missed); i >= 0 && now.Sub(p[i].lastSeen) >= leitnerGap {
+		return i
+	}
+	if len(fresh) > 0 {
+		return fresh[rand.Intn(len(fresh))]
+	}
+	return p.oldest(missed)
+}
+
+func (s leitnerScheduler) Review(p problem, correct bool, latency time.Duration, _ time.Time) problem {
+	switch {
+	case !correct:
+		p.box = 1
+	case latency <= fluentLatency:
+		p.box = min(s.box(p)+1, s.boxes)
+	default:
+		p.box = s.box(p) // Right, but slow, so needs more practice
+	}
+	return p
+}
+
+// sm2Scheduler is based on the SuperMemo 2 algorithm. Each problem has an ease factor
+// and is due again after an interval that grows by the ease each time it is answered well.
+type sm2Scheduler struct{}
+
+const (
+	sm2DefaultEase = 2.5
+	sm2MinEase     = 1.3
+	sm2Relearn     = 30 * time.Second // Wrong answers come back soon, but not right away
+)
+
+func (sm2Scheduler) Next(p problems, last problem, now time.Time) problem {
+	// Ask whatever is most overdue. New problems are due now, so missed problems
+	// come back first once their relearn time has passed.
+	best, ties := -1, 0
+	var soonest time.Time
+	for i := range p {
+		if len(p) > 1 && p[i].question == last.question {
+			continue
+		}
+		due := p[i].due
+		if due.IsZero() {
+			due = now
+		}
+		switch {
+		case best < 0 || due.Before(soonest):
+			best, ties, soonest = i, 1, due
+		case due.Equal(soonest):
+			if ties++; rand.Intn(ties) == 0 {
+				best = i // Each tie has an even chance, without keeping a list of them
+			}
+		}
+	}
+	return p[best]
+}
+
+func (sm2Scheduler) Review(p problem, correct bool, latency time.Duration, now time.Time) problem {
+	// Quality of the answer from 0-5, where below 3 is a failure
+	q := 1
+	switch {
+	case !correct:
+	case latency <= fluentLatency/2:
+		q = 5
+	case latency <= fluentLatency:
+		q = 4
+	default:
+		q = 3
+	}
+	if p.ease == 0 {
+		p.ease = sm2DefaultEase
+	}
+	p.ease = max(p.ease+0.1-float64(5-q)*(0.08+float64(5-q)*0.02), sm2MinEase)
+
+	if q < 3 {
+		p.reps = 0
+		p.interval = sm2Relearn
+	} else {
+		p.reps++
+		switch p.reps {
+		case 1:
+			p.interval = 24 * time.Hour
+		case 2:
+			p.interval = 6 * 24 * time.Hour
+		default:
+			p.interval = time.Duration(math.Round(float64(p.interval) * p.ease))
+		}
+	}
+	p.due = now.Add(p.interval)
+	return p
+}
+
+// oldest returns the index of the problem in idx that was seen longest ago, with never
+// seen problems first, or -1 when idx is empty
+func (p problems) oldest(idx []int) int {
+	best, ties := -1, 0
+	for _, i := range idx {
+		switch {
+		case best < 0 || p[i].lastSeen.Before(p[best].lastSeen):
+			best, ties = i, 1
+		case p[i].lastSeen.Equal(p[best].lastSeen):
+			if ties++; rand.Intn(ties) == 0 {
+				best = i // Each tie has an even chance, without keeping a list of them
+			}
+		}
+	}
+	return best
+}
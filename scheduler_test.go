@@ -0,0 +1,183 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeitnerReview(t *testing.T) {
+	s := leitnerScheduler{boxes: 5}
+	now := time.Now()
+	tests := []struct {
+		name    string
+		box     int
+		correct bool
+		latency time.Duration
+		want    int
+	}{
+		{"new and quick", 0, true, time.Second, 2},
+		{"quick moves up", 2, true, time.Second, 3},
+		{"top box stays", 5, true, time.Second, 5},
+		{"slow stays", 3, true, fluentLatency + time.Second, 3},
+		{"new and slow", 0, true, fluentLatency + time.Second, 1},
+		{"wrong goes back", 4, false, time.Second, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Review(problem{box: tt.box}, tt.correct, tt.latency, now).box; got != tt.want {
+				t.Errorf("box %d, got box %d, want %d", tt.box, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeitnerNext(t *testing.T) {
+	s := leitnerScheduler{boxes: 5}
+	now := time.Now()
+	p := NewMulProblems(2)
+	for i := range p {
+		p[i].box, p[i].lastSeen = 5, now
+	}
+	p[3].box, p[3].lastSeen = 1, now.Add(-time.Minute)
+	p[4].box, p[4].lastSeen = 1, now.Add(-time.Hour)
+
+	// The low box is picked far more often, and the oldest in it first
+	low := 0
+	for range 100 {
+		if got := s.Next(p, problem{}, now); got.box == 1 {
+			if got.question != p[4].question {
+				t.Fatalf("got %s, want the oldest in the box %s", got.question, p[4].question)
+			}
+			low++
+		}
+	}
+	if low < 75 {
+		t.Errorf("got the first box %d times in 100, want most of them", low)
+	}
+	for range 100 {
+		if got := s.Next(p, p[4], now); got.question == p[4].question {
+			t.Fatalf("got %s again right after asking it", got.question)
+		}
+	}
+}
+
+func TestSM2Review(t *testing.T) {
+	var s sm2Scheduler
+	now := time.Now()
+
+	p := s.Review(problem{}, true, time.Second, now)
+	if p.reps != 1 || p.interval != 24*time.Hour || !p.due.Equal(now.Add(24*time.Hour)) || p.ease <= sm2DefaultEase {
+		t.Errorf("quick first answer gave %d reps, %v interval, ease %.2f", p.reps, p.interval, p.ease)
+	}
+	p = s.Review(p, true, time.Second, now)
+	if p.reps != 2 || p.interval != 6*24*time.Hour {
+		t.Errorf("quick second answer gave %d reps, %v interval", p.reps, p.interval)
+	}
+	p = s.Review(p, true, fluentLatency+time.Second, now)
+	if p.reps != 3 || p.interval <= 6*24*time.Hour {
+		t.Errorf("slow third answer gave %d reps, %v interval, want it to keep growing", p.reps, p.interval)
+	}
+
+	ease := p.ease
+	p = s.Review(p, false, time.Second, now)
+	if p.reps != 0 || p.interval != sm2Relearn || !p.due.Equal(now.Add(sm2Relearn)) || p.ease >= ease {
+		t.Errorf("wrong answer gave %d reps, %v interval, ease %.2f", p.reps, p.interval, p.ease)
+	}
+	for range 20 {
+		p = s.Review(p, false, time.Second, now)
+	}
+	if p.ease != sm2MinEase {
+		t.Errorf("got ease %.2f after many wrong answers, want %.2f", p.ease, sm2MinEase)
+	}
+}
+
+func TestSM2Next(t *testing.T) {
+	var s sm2Scheduler
+	now := time.Now()
+	p := NewMulProblems(2)
+	for i := range p {
+		p[i].due = now.Add(time.Hour)
+	}
+	p[5].due = now.Add(-time.Minute)
+	if got := s.Next(p, problem{}, now); got.question != p[5].question {
+		t.Errorf("got %s, want the overdue %s", got.question, p[5].question)
+	}
+	if got := s.Next(p, p[5], now); got.question == p[5].question {
+		t.Errorf("got %s again right after asking it", got.question)
+	}
+	p[7].due = time.Time{}
+	if got := s.Next(p, p[5], now); got.question != p[7].question {
+		t.Errorf("got %s, want the new %s", got.question, p[7].question)
+	}
+}
+
+func TestLeitnerMissedComesBack(t *testing.T) {
+	s := leitnerScheduler{boxes: 5}
+	now := time.Now()
+	p := NewMulProblems(0)
+
+	// Miss the first problem, then answer everything else right, every 5 seconds
+	var last problem
+	missed := -1
+	for n := range 20 {
+		i := p.IndexOf(s.Next(p, last, now))
+		if n > 0 && i == missed {
+			if n > 10 {
+				t.Errorf("missed %s came back after %d answers, want it within 10", p[i].question, n)
+			}
+			return
+		}
+		if n == 0 {
+			missed = i
+		}
+		p[i].seen++
+		p[i].lastSeen = now
+		p[i] = s.Review(p[i], n > 0, time.Second, now)
+		last = p[i]
+		now = now.Add(5 * time.Second)
+	}
+	t.Errorf("missed %s never came back, with %d new problems waiting", p[missed].question, len(p))
+}
+
+func TestLeitnerFirstBox(t *testing.T) {
+	s := leitnerScheduler{boxes: 5}
+	now := time.Now()
+	p := NewMulProblems(2)
+	idx := []int{0, 1, 2}
+	p[1].box, p[1].lastSeen = 1, now.Add(-time.Second)
+	if got := s.firstBox(p, idx, now); got == 1 {
+		t.Errorf("got the problem missed a second ago, want a new one first")
+	}
+	p[1].lastSeen = now.Add(-leitnerGap)
+	if got := s.firstBox(p, idx, now); got != 1 {
+		t.Errorf("got %s, want the missed %s once it has waited", p[got].question, p[1].question)
+	}
+	if got := s.firstBox(p, []int{1}, now.Add(-leitnerGap)); got != 1 {
+		t.Errorf("got %d, want the missed problem when there's nothing new", got)
+	}
+}
+
+func TestOldest(t *testing.T) {
+	now := time.Now()
+	p := NewMulProblems(2)
+	for i := range p {
+		p[i].lastSeen = now.Add(-time.Duration(i) * time.Minute)
+	}
+	var all []int
+	for i := range p {
+		all = append(all, i)
+	}
+	if got := p.oldest(all); got != len(p)-1 {
+		t.Errorf("got %d, want %d", got, len(p)-1)
+	}
+	if got := p.oldest([]int{0, 3, 5}); got != 5 {
+		t.Errorf("got %d, want the oldest of the ones asked about, 5", got)
+	}
+	p[2].lastSeen = time.Time{}
+	if got := p.oldest(all); got != 2 {
+		t.Errorf("got %d, want the never seen 2", got)
+	}
+	if got := p.oldest(nil); got != -1 {
+		t.Errorf("got %d, want -1 for none", got)
+	}
+}
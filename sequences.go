@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// NewSequenceProblem hides one number of the sequence, and explains the rule in the solution
+func NewSequenceProblem(seq []int, hide int, rule string) problem {
+	shown := make([]string, len(seq))
+	for i, n := range seq {
+		shown[i] = strconv.Itoa(n)
+	}
+	full := strings.Join(shown, ", ")
+	shown[hide] = "?"
+	q := strings.Join(shown, ", ")
+
+	prob := NewProblem(q, intAnswer(seq[hide]))
+	prob.prompt = "What number is missing?\n\n" + q
+	prob.solution = fmt.Sprintf("%s, %s each time", full, rule)
+	return prob
+}
+
+// NewSkipCountProblems counts by table from every start in the table, with each of the
+// numbers missing in turn, or does every table when table is zero
+func NewSkipCountProblems(table, length int) problems {
+	var p problems
+	if table == 0 {
+		for x := 1; x <= mathTableEnd; x++ {
+			p = append(p, NewSkipCountProblems(x, length)...)
+		}
+		return p
+	}
+	for start := 1; start+length-1 <= mathTableEnd; start++ {
+		seq := make([]int, length)
+		for i := range seq {
+			seq[i] = (start + i) * table
+		}
+		for hide := range seq {
+			p = append(p, NewSequenceProblem(seq, hide, fmt.Sprintf("count by %d", table)))
+		}
+	}
+	return p
+}
+
+// NewSequenceProblems skip counts by table, and adds random number patterns that go up
+// or down by a step, or grow by multiplying, like 3, 6, 12, 24. When table isn't zero,
+// it's the step for the patterns too.
+func NewSequenceProblems(table, length int) problems {
+	p := NewSkipCountProblems(table, length)
+
+	patterns := NewSampledProblems(sampleSize, func() problem {
+		step := table
+		if step == 0 {
+			step = 1 + rand.Intn(mathTableEnd)
+		}
+		seq := make([]int, length)
+		rule := fmt.Sprintf("add %d", step)
+
+		switch {
+		case rand.Intn(3) == 0 && (table == 0 || table == 2 || table == 3):
+			// Multiplying, like 3, 6, 12, 24, kept small as it grows fast
+			ratio := table
+			if ratio == 0 {
+				ratio = 2 + rand.Intn(2)
+			}
+			seq[0] = 1 + rand.Intn(3)
+			for i := 1; i < length; i++ {
+				seq[i] = seq[i-1] * ratio
+			}
+			rule = fmt.Sprintf("multiply by %d", ratio)
+		case rand.Intn(2) == 0:
+			// Counting down, but not past zero
+			seq[0] = step*(length-1) + rand.Intn(20)
+			for i := 1; i < length; i++ {
+				seq[i] = seq[i-1] - step
+			}
+			rule = fmt.Sprintf("subtract %d", step)
+		default:
+			seq[0] = rand.Intn(20)
+			for i := 1; i < length; i++ {
+				seq[i] = seq[i-1] + step
+			}
+		}
+		return NewSequenceProblem(seq, rand.Intn(length), rule)
+	})
+
+	for _, prob := range patterns {
+		if p.IndexOf(prob) < 0 { // Skip counting may have it already
+			p = append(p, prob)
+		}
+	}
+	return p
+}
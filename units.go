@@ -0,0 +1,134 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//go:embed templates/units.json
+var unitsJSON []byte
+
+// unitTable is the units that can be converted, and the other names each unit goes by
+type unitTable struct {
+	Units       map[string][]string `json:"units"` // Other names for each unit, like "cm": ["centimeters"]
+	Conversions []conversion        `json:"conversions"`
+}
+
+// conversion is how many of the small unit make one of the big unit, like 100 cm in a m
+type conversion struct {
+	Big    string `json:"big"`
+	Small  string `json:"small"`
+	Factor int    `json:"factor"`
+	Max    int    `json:"max"` // Most of the big unit to use, defaults to 10
+}
+
+// loadUnitTable reads the built in units, and adds the ones in path, so teachers can add their own
+func loadUnitTable(path string) (unitTable, error) {
+	var table unitTable
+	if err := json.Unmarshal(unitsJSON, &table); err != nil {
+		return table, err
+	}
+	if path == "" {
+		return table, table.validate()
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return table, err
+	}
+	var extra unitTable
+	if err := json.Unmarshal(b, &extra); err != nil {
+		return table, fmt.Errorf("unable to read units from %s - %w", path, err)
+	}
+	maps.Copy(table.Units, extra.Units)
+	table.Conversions = append(table.Conversions, extra.Conversions...)
+	return table, table.validate()
+}
+
+func (table unitTable) validate() error {
+	for _, c := range table.Conversions {
+		if c.Big == "" || c.Small == "" || c.Big == c.Small {
+			return fmt.Errorf("unit conversion %s to %s needs two different units", c.Big, c.Small)
+		}
+		if c.Factor < 2 {
+			return fmt.Errorf("unit conversion %s to %s needs a factor of at least 2", c.Big, c.Small)
+		}
+		if c.Max < 0 {
+			return fmt.Errorf("unit conversion %s to %s has a negative max", c.Big, c.Small)
+		}
+	}
+	return nil
+}
+
+// names is every way to write unit, like "cm", "centimeter" and "centimeters"
+func (table unitTable) names(unit string) []string {
+	return append([]string{unit}, table.Units[unit]...)
+}
+
+// unitAnswer is a whole number of a unit, where writing the unit is optional
+type unitAnswer struct {
+	value int
+	unit  string   // The unit the player wrote, if any
+	names []string // Every way to write the answer's unit
+}
+
+// Parse reads "300", "300 cm", "300cm" or "300 centimeters"
+func (a unitAnswer) Parse(s string) (answer, error) {
+	s = strings.TrimSpace(s)
+	number, unit := s, ""
+	if i := strings.IndexFunc(s, unicode.IsLetter); i >= 0 {
+		number, unit = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i:])
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(normalizeMinus(number), ",", ""))
+	if err != nil {
+		return nil, errors.New("please enter a number, like 300 or 300 " + a.names[0])
+	}
+	return unitAnswer{value: n, unit: unit}, nil
+}
+
+func (a unitAnswer) Check(given answer) (bool, string) {
+	g, ok := given.(unitAnswer)
+	switch {
+	case !ok:
+		return false, ""
+	case g.unit != "" && !slices.ContainsFunc(a.names, func(name string) bool { return strings.EqualFold(name, g.unit) }):
+		return false, fmt.Sprintf("Check the units, the answer is in %s.", a.names[0])
+	}
+	return g.value == a.value, ""
+}
+
+func (a unitAnswer) String() string {
+	return fmt.Sprintf("%d %s", a.value, a.names[0])
+}
+
+// NewUnitProblems converts both ways between the units of each conversion in the table
+func NewUnitProblems(table unitTable) problems {
+	var p problems
+	for _, c := range table.Conversions {
+		limit := c.Max
+		if limit == 0 {
+			limit = 10
+		}
+		for n := 1; n <= limit; n++ {
+			big, small := fmt.Sprintf("%d %s", n, c.Big), fmt.Sprintf("%d %s", n*c.Factor, c.Small)
+
+			prob := NewProblem(big+" = ? "+c.Small, unitAnswer{value: n * c.Factor, names: table.names(c.Small)})
+			prob.prompt = prob.question
+			prob.solution = fmt.Sprintf("%s = %d x %d %s = %s", big, n, c.Factor, c.Small, small)
+			p = append(p, prob)
+
+			prob = NewProblem(small+" = ? "+c.Big, unitAnswer{value: n, names: table.names(c.Big)})
+			prob.prompt = prob.question
+			prob.solution = fmt.Sprintf("%s = %d / %d %s = %s", small, n*c.Factor, c.Factor, c.Big, big)
+			p = append(p, prob)
+		}
+	}
+	return p
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestUnitAnswer(t *testing.T) {
+	cm := unitAnswer{value: 300, names: []string{"cm", "centimeters", "centimeter"}}
+	checkAnswers(t, []answerTest{
+		{"number", cm, "300", true, "", false},
+		{"unit", cm, "300 cm", true, "", false},
+		{"attached", cm, "300cm", true, "", false},
+		{"other name", cm, "300 Centimeters", true, "", false},
+		{"comma", unitAnswer{value: 3000, names: []string{"g"}}, "3,000 g", true, "", false},
+		{"wrong number", cm, "30 cm", false, "", false},
+		{"wrong unit", cm, "300 m", false, "Check the units, the answer is in cm.", false},
+		{"no number", cm, "cm", false, "", true},
+	})
+	checkRoundTrip(t, cm)
+}
+
+func TestLoadUnitTable(t *testing.T) {
+	table, err := loadUnitTable("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Conversions) == 0 {
+		t.Fatal("got no built in conversions")
+	}
+	for _, c := range table.Conversions {
+		if len(table.names(c.Big)) == 0 || len(table.names(c.Small)) == 0 {
+			t.Errorf("%s to %s has no names", c.Big, c.Small)
+		}
+	}
+	if len(NewUnitProblems(table)) == 0 {
+		t.Error("got no unit problems")
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed templates/word-problems.json
+var wordProblemsJSON []byte
+
+// wordBank is the templates for word problems and the names and objects to fill them in with
+type wordBank struct {
+	Names     []string       `json:"names"`
+	Objects   []string       `json:"objects"`
+	Templates []wordTemplate `json:"templates"`
+}
+
+// wordTemplate is a word problem with named slots, like "{name} has {a} {object}.".
+// The slots are {name}, {friend}, {object}, {a} and {b}, where the answer is a op b.
+type wordTemplate struct {
+	Text    string   `json:"text"`
+	Op      string   `json:"op"`      // One of add, sub, mul or div
+	Objects []string `json:"objects"` // Use these instead of the bank's objects
+	Min     int      `json:"min"`     // Smallest number to use, defaults to 2
+	Max     int      `json:"max"`     // Biggest number to use, defaults to 20
+}
+
+// loadWordBank reads the built in templates, and adds the ones in path, so teachers can add their own
+func loadWordBank(path string) (wordBank, error) {
+	var bank wordBank
+	if err := json.Unmarshal(wordProblemsJSON, &bank); err != nil {
+		return bank, err
+	}
+	if path == "" {
+		return bank, bank.validate()
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return bank, err
+	}
+	var extra wordBank
+	if err := json.Unmarshal(b, &extra); err != nil {
+		return bank, fmt.Errorf("unable to read word problems from %s - %w", path, err)
+	}
+	bank.Names = append(bank.Names, extra.Names...)
+	bank.Objects = append(bank.Objects, extra.Objects...)
+	bank.Templates = append(bank.Templates, extra.Templates...)
+	return bank, bank.validate()
+}
+
+func (bank wordBank) validate() error {
+	if len(bank.Names) < 2 {
+		return errors.New("word problems need at least two names")
+	}
+	for _, t := range bank.Templates {
+		if _, err := parseOps(t.Op); err != nil || strings.Contains(t.Op, ",") {
+			return fmt.Errorf("word problem %q must have an op of add, sub, mul or div", t.Text)
+		}
+		if strings.Contains(t.Text, "{object}") && len(t.Objects)+len(bank.Objects) == 0 {
+			return fmt.Errorf("word problem %q needs objects", t.Text)
+		}
+		low, high := t.bounds()
+		if low < 1 {
+			return fmt.Errorf("word problem %q must have a min of at least 1", t.Text)
+		}
+		if high < low {
+			return fmt.Errorf("word problem %q has a max of %d, less than its min of %d", t.Text, high, low)
+		}
+	}
+	return nil
+}
+
+// bounds is the smallest and biggest number to use, after the defaults
+func (t wordTemplate) bounds() (int, int) {
+	low, high := t.Min, t.Max
+	if low == 0 {
+		low = 2 // So we don't get "1 apples"
+	}
+	if high == 0 {
+		high = 20
+	}
+	return low, high
+}
+
+// NewWordProblems fills in random templates for the operations
+func NewWordProblems(bank wordBank, ops []mode) problems {
+	var templates []wordTemplate
+	for _, t := range bank.Templates {
+		if slices.ContainsFunc(ops, func(op mode) bool { return op.String() == t.Op }) {
+			templates = append(templates, t)
+		}
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	return NewSampledProblems(sampleSize, func() problem {
+		t := templates[rand.Intn(len(templates))]
+		low, high := t.bounds()
+		a, b := low+rand.Intn(high-low+1), low+rand.Intn(high-low+1)
+
+		var solution string
+		var ans int
+		switch t.Op {
+		case "add":
+			solution, ans = fmt.Sprintf("%d + %d", a, b), a+b
+		case "sub":
+			a, b = max(a, b), min(a, b) // Don't do negative answers
+			solution, ans = fmt.Sprintf("%d - %d", a, b), a-b
+		case "mul":
+			solution, ans = fmt.Sprintf("%d x %d", a, b), a*b
+		case "div":
+			a *= b // So it divides evenly
+			solution, ans = fmt.Sprintf("%d / %d", a, b), a/b
+		}
+
+		objects := t.Objects
+		if len(objects) == 0 {
+			objects = bank.Objects
+		}
+		var object string
+		if len(objects) > 0 {
+			object = objects[rand.Intn(len(objects))]
+		}
+		names := rand.Perm(len(bank.Names))
+		q := strings.NewReplacer(
+			"{name}", bank.Names[names[0]],
+			"{friend}", bank.Names[names[1]],
+			"{object}", object,
+			"{a}", strconv.Itoa(a),
+			"{b}", strconv.Itoa(b),
+		).Replace(t.Text)
+
+		prob := NewProblem(q, intAnswer(ans))
+		prob.prompt = q
+		prob.solution = fmt.Sprintf("%s = %d", solution, ans)
+		return prob
+	})
+}